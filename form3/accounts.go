@@ -2,18 +2,24 @@ package form3
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"reflect"
 )
 
 const (
 	// accountsPath URL path to accounts resources.
 	accountsPath = "organisation/accounts"
+	// accountsType is the JSON:API type of account resources.
+	accountsType = "accounts"
 )
 
 // Account represents a single bank account that is registered with Form3.
 type Account struct {
 	Data *AccountData `json:"data"`
+	// Included holds the related resources sent along with the account, see AccountsService.Related.
+	Included []json.RawMessage `json:"included,omitempty"`
 }
 
 // AccountList represents a list of bank accounts that is registered with Form3.
@@ -24,12 +30,18 @@ type AccountList struct {
 // AccountData represents the main attributes for a given Form3 account.
 type AccountData struct {
 	Type           string             `json:"type"`
-	ID             string             `json:"id"`
-	OrganisationID string             `json:"organisation_id"`
+	ID             UUID               `json:"id"`
+	OrganisationID UUID               `json:"organisation_id"`
 	Version        int                `json:"version"`
 	Attributes     *AccountAttributes `json:"attributes"`
-	CreatedOn      string             `json:"created_on,omitempty"`
-	ModifiedOn     string             `json:"modified_on,omitempty"`
+	CreatedOn      *Timestamp         `json:"created_on,omitempty"`
+	ModifiedOn     *Timestamp         `json:"modified_on,omitempty"`
+	// Relationships links the account to related resources, e.g. its master account.
+	Relationships Relationships `json:"relationships,omitempty"`
+	// Extra holds the members of the data that this package does not know about.
+	// They are kept when decoding and sent back when encoding, so that a fetch
+	// followed by an update does not erase fields added to the API later.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // AccountAttributes represents the available attribute fields.
@@ -51,72 +63,162 @@ type AccountAttributes struct {
 	SecondaryIdentification string   `json:"secondary_identification,omitempty"`
 	Switched                bool     `json:"switched,omitempty"`
 	Status                  string   `json:"status"`
+	// Extra holds the attributes that this package does not know about, see AccountData.Extra.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+var (
+	accountDataFields       = jsonFields(reflect.TypeOf(AccountData{}))
+	accountAttributesFields = jsonFields(reflect.TypeOf(AccountAttributes{}))
+)
+
+// MarshalJSON encodes d, including its Extra members.
+func (d AccountData) MarshalJSON() ([]byte, error) {
+	type plain AccountData
+	b, err := json.Marshal(plain(d))
+	if err != nil {
+		return nil, err
+	}
+	return mergeFields(b, d.Extra)
+}
+
+// UnmarshalJSON decodes d, keeping unknown members in Extra.
+func (d *AccountData) UnmarshalJSON(b []byte) error {
+	type plain AccountData
+	if err := json.Unmarshal(b, (*plain)(d)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(b, accountDataFields)
+	if err != nil {
+		return err
+	}
+	d.Extra = extra
+	return nil
+}
+
+// MarshalJSON encodes a, including its Extra attributes.
+func (a AccountAttributes) MarshalJSON() ([]byte, error) {
+	type plain AccountAttributes
+	b, err := json.Marshal(plain(a))
+	if err != nil {
+		return nil, err
+	}
+	return mergeFields(b, a.Extra)
+}
+
+// UnmarshalJSON decodes a, keeping unknown attributes in Extra.
+func (a *AccountAttributes) UnmarshalJSON(b []byte) error {
+	type plain AccountAttributes
+	if err := json.Unmarshal(b, (*plain)(a)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(b, accountAttributesFields)
+	if err != nil {
+		return err
+	}
+	a.Extra = extra
+	return nil
+}
+
+// ResourceID returns the ID of the account.
+func (d *AccountData) ResourceID() UUID {
+	return d.ID
+}
+
+// SetResourceID sets the ID of the account.
+func (d *AccountData) SetResourceID(id UUID) {
+	d.ID = id
 }
 
 // AccountsService handles the communication with the account related
 // methods of the Form3 API.
 //
 // Form3 API docs: https://api-docs.form3.tech/api.html?http#organisation-accounts
-type AccountsService service
+type AccountsService struct {
+	resources *ResourceService[AccountData]
+}
 
 // Create registers an existing bank account with Form3 or create a new one.
 // The country attribute must be specified as a minimum.
 // Depending on the country, other attributes such as bank_id and bic are mandatory.
+// If Client.GenerateIDs is set and the account has no ID, a random one is assigned to account before sending.
 func (s *AccountsService) Create(ctx context.Context, account *Account) (*Account, *http.Response, error) {
-	request, err := s.client.NewRequest(http.MethodPost, accountsPath, account)
-	if err != nil {
-		return nil, nil, err
+	var data *AccountData
+	if account != nil {
+		data = account.Data
 	}
 
-	acc := new(Account)
-	resp, err := s.client.Do(ctx, request, acc)
+	created, resp, err := s.resources.Create(ctx, data)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return acc, resp, nil
+	return &Account{Data: created}, resp, nil
 }
 
 // Fetch gets a single account using the account ID.
+// An error wrapping ErrInvalidUUID is returned if accountID is not a valid UUID.
 func (s *AccountsService) Fetch(ctx context.Context, accountID string) (*Account, *http.Response, error) {
-	path := fmt.Sprintf("%s/%s", accountsPath, accountID)
-	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	doc, resp, err := s.resources.FetchDocument(ctx, accountID)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	acc := new(Account)
-	resp, err := s.client.Do(ctx, request, acc)
+	return &Account{Data: doc.Data, Included: doc.Included}, resp, nil
+}
+
+// Related returns the accounts related to account under name, e.g. "master_account".
+// Accounts in account.Included are used as they are, the others are fetched.
+// An error wrapping ErrRelationshipNotFound is returned if there are none, and one wrapping
+// ErrRelationshipType if a related resource is not an account.
+func (s *AccountsService) Related(ctx context.Context, account *Account, name string) ([]*Account, error) {
+	if account == nil || account.Data == nil {
+		return nil, errors.New("account data should not be nil")
+	}
+	data, err := Resolve[AccountData](ctx, s.resources, accountsType, account.Data.Relationships, name, account.Included)
 	if err != nil {
-		return nil, resp, err
+		return nil, err
 	}
 
-	return acc, resp, nil
+	related := make([]*Account, len(data))
+	for i, d := range data {
+		related[i] = &Account{Data: d}
+	}
+	return related, nil
 }
 
 // List lists all accounts. Supports pagination.
 func (s *AccountsService) List(ctx context.Context, pageNumber int, pageSize int) (*AccountList, *http.Response, error) {
-	path := fmt.Sprintf("%s?page[number]=%d&page[size]=%d", accountsPath, pageNumber, pageSize)
-	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	data, resp, err := s.resources.List(ctx, &ListOptions{PageNumber: pageNumber, PageSize: pageSize})
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
+	}
+
+	return &AccountList{Data: data}, resp, nil
+}
+
+// Update patches an account. The ID of the account is read from account.Data.
+// Unknown fields kept in Extra are sent back unchanged.
+func (s *AccountsService) Update(ctx context.Context, account *Account) (*Account, *http.Response, error) {
+	if account == nil || account.Data == nil {
+		return nil, nil, errors.New("account data should not be nil")
 	}
 
-	acc := new(AccountList)
-	resp, err := s.client.Do(ctx, request, &acc)
+	data, resp, err := s.resources.Update(ctx, account.Data)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return acc, resp, nil
+	return &Account{Data: data}, resp, nil
 }
 
-// Delete deletes an account by ID and given version
+// Delete deletes an account by ID and given version.
+// An error wrapping ErrInvalidUUID is returned if accountID is not a valid UUID.
 func (s *AccountsService) Delete(ctx context.Context, accountID string, version int) (*http.Response, error) {
-	path := fmt.Sprintf("%s/%s?version=%d", accountsPath, accountID, version)
-	request, err := s.client.NewRequest(http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	return s.client.Do(ctx, request, nil)
+	return s.resources.Delete(ctx, accountID, version)
+}
+
+// Iterate returns an iterator over all accounts matching opts, see ResourceService.Iterate.
+func (s *AccountsService) Iterate(opts *ListOptions) *Iterator[AccountData] {
+	return s.resources.Iterate(opts)
 }
@@ -3,15 +3,23 @@ package form3
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/martoup/go-form3/form3/form3test"
 )
 
+const testAccountID = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+
 var expectedAccount = &Account{Data: &AccountData{
 	Type:           "accounts",
-	ID:             "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+	ID:             testAccountID,
 	OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
 	Version:        0,
 	Attributes: &AccountAttributes{
@@ -29,6 +37,43 @@ var expectedAccount = &Account{Data: &AccountData{
 	},
 }}
 
+// accountsFixture holds the accountapi interactions recorded by test/integration.go, see the README.
+var accountsFixture = filepath.Join("testdata", "accounts.json")
+
+// replayAccounts returns a client that replays accountsFixture, and the recorder serving it.
+// The test is skipped if the fixture has not been recorded yet.
+func replayAccounts(t *testing.T) (*Client, *form3test.Recorder) {
+	if _, err := os.Stat(accountsFixture); errors.Is(err, fs.ErrNotExist) {
+		t.Skipf("%s has not been recorded from the accountapi", accountsFixture)
+	}
+	recorder, err := form3test.NewRecorder(accountsFixture, nil)
+	if err != nil {
+		t.Fatalf("Failed to load fixture: %v", err)
+	}
+	c, err := NewClient("http://localhost:8080", &http.Client{Transport: recorder})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return c, recorder
+}
+
+// testReplayedAccount checks an account returned by the accountapi against expectedAccount.
+// The server sets the timestamps, so they are only checked to be present.
+func testReplayedAccount(t *testing.T, method string, data *AccountData) {
+	t.Helper()
+	if data == nil {
+		t.Fatalf("%s returned no data", method)
+	}
+	if data.CreatedOn == nil || data.ModifiedOn == nil {
+		t.Errorf("%s returned no timestamps", method)
+	}
+	got := *data
+	got.CreatedOn, got.ModifiedOn = nil, nil
+	if !reflect.DeepEqual(&got, expectedAccount.Data) {
+		t.Errorf("%s returned %+v, expected %+v", method, &got, expectedAccount.Data)
+	}
+}
+
 func TestAccountsService_Create(t *testing.T) {
 	setup()
 	defer teardown()
@@ -57,7 +102,7 @@ func TestAccountsService_Fetch(t *testing.T) {
 	setup()
 	defer teardown()
 
-	mux.HandleFunc("/v1/"+accountsPath+"/1", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, http.MethodGet)
 		response, err := json.Marshal(expectedAccount)
 		if err != nil {
@@ -66,7 +111,7 @@ func TestAccountsService_Fetch(t *testing.T) {
 		fmt.Fprint(w, string(response))
 	})
 
-	acct, _, err := client.Accounts.Fetch(ctx, "1")
+	acct, _, err := client.Accounts.Fetch(ctx, testAccountID)
 	if err != nil {
 		t.Errorf("Accounts.Fetch returned error: %v", err)
 	}
@@ -126,12 +171,12 @@ func TestAccountsService_Delete(t *testing.T) {
 	setup()
 	defer teardown()
 
-	mux.HandleFunc("/v1/"+accountsPath+"/1", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, http.MethodDelete)
 		testQueryParam(t, r, "version", "0")
 	})
 
-	_, err := client.Accounts.Delete(ctx, "1", 0)
+	_, err := client.Accounts.Delete(ctx, testAccountID, 0)
 	if err != nil {
 		t.Errorf("Accounts.Delete returned error: %v", err)
 	}
@@ -141,11 +186,11 @@ func TestAccountsService_DeleteNotFound(t *testing.T) {
 	setup()
 	defer teardown()
 
-	mux.HandleFunc("/v1/"+accountsPath+"/1", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
 	})
 
-	resp, err := client.Accounts.Delete(ctx, "1", 0)
+	resp, err := client.Accounts.Delete(ctx, testAccountID, 0)
 
 	if err == nil {
 		t.Errorf("Accounts.Delete should return an error on 404")
@@ -155,3 +200,98 @@ func TestAccountsService_DeleteNotFound(t *testing.T) {
 		t.Errorf("Response Code not correct. Expected %v got %v", resp.StatusCode, resp.StatusCode)
 	}
 }
+
+func TestAccountsService_replay(t *testing.T) {
+	client, recorder := replayAccounts(t)
+
+	created, _, err := client.Accounts.Create(ctx, expectedAccount)
+	if err != nil {
+		t.Fatalf("Accounts.Create returned error: %v", err)
+	}
+	testReplayedAccount(t, "Accounts.Create", created.Data)
+
+	fetched, _, err := client.Accounts.Fetch(ctx, testAccountID)
+	if err != nil {
+		t.Fatalf("Accounts.Fetch returned error: %v", err)
+	}
+	testReplayedAccount(t, "Accounts.Fetch", fetched.Data)
+
+	list, _, err := client.Accounts.List(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("Accounts.List returned error: %v", err)
+	}
+	if len(list.Data) != 1 {
+		t.Fatalf("Accounts.List returned %d accounts, want 1", len(list.Data))
+	}
+	testReplayedAccount(t, "Accounts.List", list.Data[0])
+
+	if _, err := client.Accounts.Delete(ctx, testAccountID, 0); err != nil {
+		t.Errorf("Accounts.Delete returned error: %v", err)
+	}
+	_, resp, err := client.Accounts.Fetch(ctx, testAccountID)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Accounts.Fetch of the deleted account returned %v, want a 404 error", err)
+	}
+
+	if unused := recorder.Unused(); len(unused) != 0 {
+		t.Errorf("Interactions were not replayed: %+v", unused)
+	}
+}
+
+func TestAccountsService_UpdatePreservesUnknownFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const stored = `{"data":{"type":"accounts","id":"` + testAccountID + `","organisation_id":"` + testOrganisationID + `","version":1,` +
+		`"processing_service":"uk","attributes":{"country":"GB","name":["Sam"],"status":"confirmed","name_matching_status":"supported"}}}`
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, stored)
+		case http.MethodPatch:
+			var body struct {
+				Data map[string]json.RawMessage `json:"data"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+				return
+			}
+			if got := string(body.Data["processing_service"]); got != `"uk"` {
+				t.Errorf("Update sent processing_service %s, want \"uk\"", got)
+			}
+			var attributes map[string]json.RawMessage
+			_ = json.Unmarshal(body.Data["attributes"], &attributes)
+			if got := string(attributes["name_matching_status"]); got != `"supported"` {
+				t.Errorf("Update sent name_matching_status %s, want \"supported\"", got)
+			}
+			if got := string(attributes["bank_id"]); got != `"400300"` {
+				t.Errorf("Update sent bank_id %s, want \"400300\"", got)
+			}
+			fmt.Fprint(w, stored)
+		}
+	})
+
+	account, _, err := client.Accounts.Fetch(ctx, testAccountID)
+	if err != nil {
+		t.Fatalf("Accounts.Fetch returned error: %v", err)
+	}
+	if len(account.Data.Extra) != 1 || len(account.Data.Attributes.Extra) != 1 {
+		t.Errorf("Accounts.Fetch kept %v and %v, want one unknown field each", account.Data.Extra, account.Data.Attributes.Extra)
+	}
+
+	account.Data.Attributes.BankID = "400300"
+	if _, _, err := client.Accounts.Update(ctx, account); err != nil {
+		t.Errorf("Accounts.Update returned error: %v", err)
+	}
+}
+
+func TestAccountData_noUnknownFields(t *testing.T) {
+	b, _ := json.Marshal(expectedAccount)
+	account := new(Account)
+	if err := json.Unmarshal(b, account); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if account.Data.Extra != nil || account.Data.Attributes.Extra != nil {
+		t.Errorf("Unmarshal kept %v and %v, want no unknown fields", account.Data.Extra, account.Data.Attributes.Extra)
+	}
+}
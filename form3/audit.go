@@ -0,0 +1,191 @@
+package form3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+)
+
+const (
+	// auditEntriesPath URL path to audit entries.
+	auditEntriesPath = "audit/entries"
+)
+
+// AuditEntryList represents a page of audit entries.
+type AuditEntryList struct {
+	Data []*AuditEntryData `json:"data"`
+}
+
+// AuditEntryData represents a single change of a resource.
+type AuditEntryData struct {
+	ResourceData
+	Attributes *AuditEntryAttributes `json:"attributes"`
+}
+
+// AuditEntryAttributes represents who changed a resource, when and how.
+type AuditEntryAttributes struct {
+	RecordType RecordType `json:"record_type"`
+	RecordID   UUID       `json:"record_id"`
+	// Action is the kind of change, e.g. created, updated or deleted.
+	Action      EventType  `json:"action"`
+	ActionTime  *Timestamp `json:"action_time,omitempty"`
+	ActionedBy  UUID       `json:"actioned_by,omitempty"`
+	ActorName   string     `json:"actor_name,omitempty"`
+	Description string     `json:"description,omitempty"`
+	// BeforeData and AfterData hold snapshots of the resource data before and after the change.
+	BeforeData json.RawMessage `json:"before_data,omitempty"`
+	AfterData  json.RawMessage `json:"after_data,omitempty"`
+}
+
+// Accounts decodes the before and after snapshots of an account audit entry.
+// A snapshot that is absent, e.g. before on creation, is returned as nil.
+func (a *AuditEntryAttributes) Accounts() (before *AccountData, after *AccountData, err error) {
+	if len(a.BeforeData) > 0 && string(a.BeforeData) != "null" {
+		before = new(AccountData)
+		if err := json.Unmarshal(a.BeforeData, before); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(a.AfterData) > 0 && string(a.AfterData) != "null" {
+		after = new(AccountData)
+		if err := json.Unmarshal(a.AfterData, after); err != nil {
+			return nil, nil, err
+		}
+	}
+	return before, after, nil
+}
+
+// FieldChange represents a field whose value differs between two versions of a resource.
+type FieldChange struct {
+	// Field is the JSON name of the field, e.g. "bank_id".
+	Field  string
+	Before string
+	After  string
+}
+
+// DiffAccounts returns the fields that differ between two versions of an account,
+// sorted by field name. Either version may be nil, in which case all its fields are empty.
+// Multi-value fields such as name are compared with their values joined by ";".
+// Unknown members kept in Extra are compared as JSON, under their name for members of the data
+// and prefixed with "attributes." for attributes, and relationships under "relationships." and their name.
+func DiffAccounts(before *AccountData, after *AccountData) []FieldChange {
+	if before == nil {
+		before = new(AccountData)
+	}
+	if after == nil {
+		after = new(AccountData)
+	}
+
+	b, a := diffFields(before), diffFields(after)
+	names := make([]string, 0, len(b)+len(a))
+	for name := range b {
+		names = append(names, name)
+	}
+	for name := range a {
+		if _, ok := b[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []FieldChange
+	for _, name := range names {
+		if a[name] != b[name] {
+			changes = append(changes, FieldChange{Field: name, Before: b[name], After: a[name]})
+		}
+	}
+	return changes
+}
+
+// diffFields returns the values of the fields of account compared by DiffAccounts.
+func diffFields(account *AccountData) map[string]string {
+	fields := make(map[string]string, len(csvFields))
+	for name, f := range csvFields {
+		fields[name] = f.get(account, defaultMultiValueSeparator)
+	}
+	for name, raw := range account.Extra {
+		fields[name] = compactJSON(raw)
+	}
+	if account.Attributes != nil {
+		for name, raw := range account.Attributes.Extra {
+			fields["attributes."+name] = compactJSON(raw)
+		}
+	}
+	for name, rel := range account.Relationships {
+		raw, _ := json.Marshal(rel)
+		fields["relationships."+name] = compactJSON(raw)
+	}
+	return fields
+}
+
+// compactJSON returns raw without insignificant white space, so equal values compare equal.
+func compactJSON(raw []byte) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+// AuditService handles the communication with the audit related
+// methods of the Form3 API.
+//
+// Form3 API docs: https://api-docs.form3.tech/api.html?http#audit
+type AuditService service
+
+// List lists the audit entries of a single resource, oldest first. Supports pagination.
+func (s *AuditService) List(ctx context.Context, recordType RecordType, recordID string, pageNumber int, pageSize int) (*AuditEntryList, *http.Response, error) {
+	if err := validateIDs(recordID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(auditEntriesPath, string(recordType), recordID)
+	if err != nil {
+		return nil, nil, err
+	}
+	path = pagePath(path, pageNumber, pageSize)
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(AuditEntryList)
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, nil
+}
+
+// Entries returns an iterator over all audit entries of a single resource.
+// If pageSize is not positive, a default of 100 is used.
+func (s *AuditService) Entries(recordType RecordType, recordID string, pageSize int) *AuditIterator {
+	list := func(ctx context.Context, opts *ListOptions) ([]*AuditEntryData, *http.Response, error) {
+		entries, resp, err := s.List(ctx, recordType, recordID, opts.PageNumber, opts.PageSize)
+		if err != nil {
+			return nil, resp, err
+		}
+		return entries.Data, resp, nil
+	}
+	return &AuditIterator{newIterator(list, &ListOptions{PageSize: pageSize})}
+}
+
+// AuditIterator pages through audit entries. Use it as:
+//
+//	it := client.Audit.Entries(form3.RecordTypeAccount, accountID, 0)
+//	for it.Next(ctx) {
+//		entry := it.Entry()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type AuditIterator struct {
+	*Iterator[AuditEntryData]
+}
+
+// Entry returns the current entry.
+func (it *AuditIterator) Entry() *AuditEntryData {
+	return it.Item()
+}
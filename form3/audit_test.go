@@ -0,0 +1,157 @@
+package form3
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestAuditService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+auditEntriesPath+"/accounts/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testQueryParam(t, r, "page[number]", "0")
+		testQueryParam(t, r, "page[size]", "10")
+		writeJSON(t, w, AuditEntryList{Data: []*AuditEntryData{{
+			Attributes: &AuditEntryAttributes{
+				RecordType: RecordTypeAccount,
+				RecordID:   testAccountID,
+				Action:     EventTypeCreated,
+				ActorName:  "payments-bot",
+			},
+		}}})
+	})
+
+	list, _, err := client.Audit.List(ctx, RecordTypeAccount, testAccountID, 0, 10)
+	if err != nil {
+		t.Fatalf("Audit.List returned error: %v", err)
+	}
+	if len(list.Data) != 1 || list.Data[0].Attributes.ActorName != "payments-bot" {
+		t.Errorf("Audit.List returned %+v", list)
+	}
+
+	if _, _, err := client.Audit.List(ctx, RecordTypeAccount, "..", 0, 10); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("Audit.List returned %v, want ErrInvalidUUID", err)
+	}
+	if _, _, err := client.Audit.List(ctx, "..", testAccountID, 0, 10); !errors.Is(err, ErrInvalidPathSegment) {
+		t.Errorf("Audit.List returned %v, want ErrInvalidPathSegment", err)
+	}
+}
+
+func TestAuditService_ListEscapesRecordType(t *testing.T) {
+	// http.ServeMux unescapes and cleans request paths, so the raw path is checked by a plain handler.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/v1/" + auditEntriesPath + "/accounts%2F..%2Fusers%3Fx/" + testAccountID; r.URL.EscapedPath() != want {
+			t.Errorf("Request path is %s, want %s", r.URL.EscapedPath(), want)
+		}
+		if r.URL.Query().Has("x") {
+			t.Errorf("Record type leaked into the query: %s", r.URL.RawQuery)
+		}
+		writeJSON(t, w, AuditEntryList{Data: []*AuditEntryData{}})
+	}))
+	defer server.Close()
+	client, _ := NewClient(server.URL, nil)
+
+	if _, _, err := client.Audit.List(ctx, "accounts/../users?x", testAccountID, 0, 10); err != nil {
+		t.Fatalf("Audit.List returned error: %v", err)
+	}
+}
+
+func TestAuditService_Entries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const total = 5
+	mux.HandleFunc("/v1/"+auditEntriesPath+"/accounts/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+		list := AuditEntryList{Data: []*AuditEntryData{}}
+		for i := page * 2; i < total && i < page*2+2; i++ {
+			list.Data = append(list.Data, &AuditEntryData{ResourceData: ResourceData{Version: i}})
+		}
+		writeJSON(t, w, list)
+	})
+
+	it := client.Audit.Entries(RecordTypeAccount, testAccountID, 2)
+	var versions []int
+	for it.Next(ctx) {
+		versions = append(versions, it.Entry().Version)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Iterator returned error: %v", err)
+	}
+	if want := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(versions, want) {
+		t.Errorf("Iterated versions %v, want %v", versions, want)
+	}
+}
+
+func TestAuditService_EntriesError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+auditEntriesPath+"/accounts/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	it := client.Audit.Entries(RecordTypeAccount, testAccountID, 0)
+	if it.Next(ctx) {
+		t.Errorf("Next should return false on error")
+	}
+	if it.Err() == nil {
+		t.Errorf("Err should return the request error")
+	}
+}
+
+func TestDiffAccounts(t *testing.T) {
+	before := *expectedAccount.Data
+	beforeAttributes := *before.Attributes
+	before.Attributes = &beforeAttributes
+
+	after := before
+	afterAttributes := beforeAttributes
+	afterAttributes.BankID = "400302"
+	afterAttributes.Name = []string{"Samantha Holder", "S Holder"}
+	after.Attributes = &afterAttributes
+	after.Version = 1
+
+	beforeJSON, _ := json.Marshal(&before)
+	afterJSON, _ := json.Marshal(&after)
+	entry := &AuditEntryAttributes{BeforeData: beforeJSON, AfterData: afterJSON}
+	b, a, err := entry.Accounts()
+	if err != nil {
+		t.Fatalf("Accounts returned error: %v", err)
+	}
+
+	want := []FieldChange{
+		{Field: "bank_id", Before: "400300", After: "400302"},
+		{Field: "name", Before: "Samantha Holder", After: "Samantha Holder;S Holder"},
+		{Field: "version", Before: "0", After: "1"},
+	}
+	if got := DiffAccounts(b, a); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffAccounts returned %+v, want %+v", got, want)
+	}
+
+	if got := DiffAccounts(&before, &before); len(got) != 0 {
+		t.Errorf("DiffAccounts of equal accounts returned %+v", got)
+	}
+
+	after.Extra = map[string]json.RawMessage{"processing_service": json.RawMessage(`"uk"`)}
+	afterAttributes.Extra = map[string]json.RawMessage{"name_matching_status": json.RawMessage(`"supported"`)}
+	after.Relationships = Relationships{"master_account": {Data: json.RawMessage(`{"type":"accounts","id":"` + testMasterAccountID + `"}`)}}
+	want = []FieldChange{
+		{Field: "attributes.name_matching_status", After: `"supported"`},
+		{Field: "bank_id", Before: "400300", After: "400302"},
+		{Field: "name", Before: "Samantha Holder", After: "Samantha Holder;S Holder"},
+		{Field: "processing_service", After: `"uk"`},
+		{Field: "relationships.master_account", After: `{"data":{"type":"accounts","id":"` + testMasterAccountID + `"}}`},
+		{Field: "version", Before: "0", After: "1"},
+	}
+	if got := DiffAccounts(&before, &after); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffAccounts returned %+v, want %+v", got, want)
+	}
+}
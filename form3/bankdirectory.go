@@ -0,0 +1,239 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// bankIDsPath URL path to bank ID reference data.
+	bankIDsPath = "organisation/bankids"
+	// bicsPath URL path to BIC reference data.
+	bicsPath = "organisation/bics"
+)
+
+// ErrBankNotFound is returned when a bank ID or BIC is not in the Form3 reference data.
+var ErrBankNotFound = errors.New("bank not found")
+
+// BankIDList represents a list of banks matching a bank ID lookup.
+type BankIDList struct {
+	Data []*BankIDData `json:"data"`
+}
+
+// BankIDData represents a bank identified by its national bank ID, e.g. a UK sort code.
+type BankIDData struct {
+	ResourceData
+	Attributes *BankIDAttributes `json:"attributes"`
+}
+
+// BankIDAttributes represents the reference data of a bank.
+type BankIDAttributes struct {
+	Country    string   `json:"country"`
+	BankID     string   `json:"bank_id"`
+	BankIDCode string   `json:"bank_id_code,omitempty"`
+	Bic        string   `json:"bic,omitempty"`
+	Name       string   `json:"name,omitempty"`
+	Address    []string `json:"address,omitempty"`
+	// Reachability lists the schemes through which the bank can be reached.
+	Reachability []*SchemeReachability `json:"reachability,omitempty"`
+}
+
+// SchemeReachability represents whether a bank can be reached through a scheme.
+type SchemeReachability struct {
+	Scheme    Scheme `json:"scheme"`
+	Reachable bool   `json:"reachable"`
+}
+
+// Reachable reports whether the bank can be reached through the given scheme.
+func (a *BankIDAttributes) Reachable(scheme Scheme) bool {
+	for _, r := range a.Reachability {
+		if strings.EqualFold(string(r.Scheme), string(scheme)) {
+			return r.Reachable
+		}
+	}
+	return false
+}
+
+// BICList represents a list of institutions matching a BIC lookup.
+type BICList struct {
+	Data []*BICData `json:"data"`
+}
+
+// BICData represents an institution identified by its BIC.
+type BICData struct {
+	ResourceData
+	Attributes *BICAttributes `json:"attributes"`
+}
+
+// BICAttributes represents the reference data of an institution.
+type BICAttributes struct {
+	Bic             string   `json:"bic"`
+	InstitutionName string   `json:"institution_name,omitempty"`
+	Country         string   `json:"country,omitempty"`
+	Address         []string `json:"address,omitempty"`
+	BankIDs         []string `json:"bank_ids,omitempty"`
+}
+
+// BankDirectoryService handles the communication with the bank ID and BIC
+// reference data methods of the Form3 API.
+//
+// Lookups can be cached in memory with SetCacheTTL, so repeated lookups of the
+// same bank in a batch are answered without calling the API.
+//
+// Form3 API docs: https://api-docs.form3.tech/api.html?http#bank-ids
+type BankDirectoryService struct {
+	client *Client
+	cache  *ttlCache
+}
+
+// SetCacheTTL enables caching of successful lookups for the given duration and drops all cached entries.
+// A ttl of zero or less disables the cache. The cache is shared with the views returned by
+// Client.ForOrganisation, so the ttl applies to them too.
+func (s *BankDirectoryService) SetCacheTTL(ttl time.Duration) {
+	s.cache.setTTL(ttl)
+}
+
+// LookupBankID gets the bank with the given national bank ID in the given country.
+// ErrBankNotFound is returned if there is no such bank.
+// The returned response is nil if the bank was served from the cache.
+func (s *BankDirectoryService) LookupBankID(ctx context.Context, country string, bankID string) (*BankIDData, *http.Response, error) {
+	key := "bankid/" + strings.ToUpper(country) + "/" + bankID
+	bank := new(BankIDData)
+	if s.cache.load(key, bank) {
+		return bank, nil, nil
+	}
+
+	query := url.Values{}
+	query.Set("filter[country]", country)
+	query.Set("filter[bank_id]", bankID)
+	request, err := s.client.NewRequest(http.MethodGet, bankIDsPath+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(BankIDList)
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(list.Data) == 0 {
+		return nil, resp, ErrBankNotFound
+	}
+
+	s.cache.store(key, list.Data[0])
+	return list.Data[0], resp, nil
+}
+
+// LookupBIC resolves the institution with the given BIC.
+// ErrBankNotFound is returned if there is no such institution.
+// The returned response is nil if the institution was served from the cache.
+func (s *BankDirectoryService) LookupBIC(ctx context.Context, bic string) (*BICData, *http.Response, error) {
+	key := "bic/" + strings.ToUpper(bic)
+	institution := new(BICData)
+	if s.cache.load(key, institution) {
+		return institution, nil, nil
+	}
+
+	query := url.Values{}
+	query.Set("filter[bic]", bic)
+	request, err := s.client.NewRequest(http.MethodGet, bicsPath+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(BICList)
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(list.Data) == 0 {
+		return nil, resp, ErrBankNotFound
+	}
+
+	s.cache.store(key, list.Data[0])
+	return list.Data[0], resp, nil
+}
+
+// IsReachable reports whether the bank with the given national bank ID can be reached through scheme.
+func (s *BankDirectoryService) IsReachable(ctx context.Context, country string, bankID string, scheme Scheme) (bool, error) {
+	bank, _, err := s.LookupBankID(ctx, country, bankID)
+	if err != nil {
+		return false, err
+	}
+	return bank.Attributes != nil && bank.Attributes.Reachable(scheme), nil
+}
+
+// ttlCache is an in-memory cache whose entries expire after a fixed duration.
+// Values are stored JSON encoded, so every load returns a copy callers may modify.
+// Expired entries are swept when entries are stored, at most once per ttl.
+// A cache with a ttl of zero or less is disabled and never holds any entries.
+type ttlCache struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]ttlEntry
+	nextSweep time.Time
+}
+
+type ttlEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+func newTTLCache(ttl time.Duration) *ttlCache {
+	return &ttlCache{ttl: ttl, now: time.Now, entries: make(map[string]ttlEntry)}
+}
+
+// setTTL changes the ttl of the cache and drops all entries.
+func (c *ttlCache) setTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ttl = ttl
+	c.entries = make(map[string]ttlEntry)
+}
+
+// load decodes the value stored for key into v and reports whether there was one.
+func (c *ttlCache) load(key string, v interface{}) bool {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok && !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		ok = false
+	}
+	c.mu.Unlock()
+
+	return ok && json.Unmarshal(e.value, v) == nil
+}
+
+// store stores a copy of value for key.
+func (c *ttlCache) store(key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ttl <= 0 {
+		return
+	}
+
+	now := c.now()
+	if !now.Before(c.nextSweep) {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+	c.entries[key] = ttlEntry{value: data, expires: now.Add(c.ttl)}
+}
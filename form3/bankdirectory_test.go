@@ -0,0 +1,189 @@
+package form3
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var expectedBank = &BankIDData{
+	ResourceData: ResourceData{Type: "bankids", ID: "6e8a0c2e-4f6a-4b8c-9d0e-1f2a3b4c5d6e"},
+	Attributes: &BankIDAttributes{
+		Country:    "GB",
+		BankID:     "400300",
+		BankIDCode: "GBDSC",
+		Bic:        "NWBKGB22",
+		Name:       "NatWest",
+		Reachability: []*SchemeReachability{
+			{Scheme: "FPS", Reachable: true},
+			{Scheme: SchemeBacs, Reachable: false},
+		},
+	},
+}
+
+func TestBankDirectoryService_LookupBankID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+bankIDsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testQueryParam(t, r, "filter[country]", "GB")
+		testQueryParam(t, r, "filter[bank_id]", "400300")
+		writeJSON(t, w, BankIDList{Data: []*BankIDData{expectedBank}})
+	})
+
+	bank, _, err := client.BankDirectory.LookupBankID(ctx, "GB", "400300")
+	if err != nil {
+		t.Fatalf("BankDirectory.LookupBankID returned error: %v", err)
+	}
+	if bank.Attributes.Bic != "NWBKGB22" {
+		t.Errorf("BankDirectory.LookupBankID returned %+v", bank.Attributes)
+	}
+
+	reachable, err := client.BankDirectory.IsReachable(ctx, "GB", "400300", "fps")
+	if err != nil || !reachable {
+		t.Errorf("BankDirectory.IsReachable(FPS) returned %v, %v, want true", reachable, err)
+	}
+	reachable, err = client.BankDirectory.IsReachable(ctx, "GB", "400300", SchemeBacs)
+	if err != nil || reachable {
+		t.Errorf("BankDirectory.IsReachable(BACS) returned %v, %v, want false", reachable, err)
+	}
+}
+
+func TestBankDirectoryService_LookupBIC(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+bicsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testQueryParam(t, r, "filter[bic]", "NWBKGB22")
+		writeJSON(t, w, BICList{Data: []*BICData{{
+			Attributes: &BICAttributes{Bic: "NWBKGB22", InstitutionName: "NatWest", Country: "GB"},
+		}}})
+	})
+
+	bic, _, err := client.BankDirectory.LookupBIC(ctx, "NWBKGB22")
+	if err != nil {
+		t.Fatalf("BankDirectory.LookupBIC returned error: %v", err)
+	}
+	if bic.Attributes.InstitutionName != "NatWest" {
+		t.Errorf("BankDirectory.LookupBIC returned %+v", bic.Attributes)
+	}
+}
+
+func TestBankDirectoryService_notFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+bankIDsPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, BankIDList{Data: []*BankIDData{}})
+	})
+
+	if _, _, err := client.BankDirectory.LookupBankID(ctx, "GB", "000000"); !errors.Is(err, ErrBankNotFound) {
+		t.Errorf("BankDirectory.LookupBankID returned %v, want ErrBankNotFound", err)
+	}
+}
+
+func TestBankDirectoryService_cache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/v1/"+bankIDsPath, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSON(t, w, BankIDList{Data: []*BankIDData{expectedBank}})
+	})
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	client.BankDirectory.SetCacheTTL(time.Minute)
+	client.BankDirectory.cache.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.BankDirectory.LookupBankID(ctx, "GB", "400300"); err != nil {
+			t.Fatalf("BankDirectory.LookupBankID returned error: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("API called %d times, want 1", calls)
+	}
+
+	now = now.Add(time.Minute)
+	if _, resp, _ := client.BankDirectory.LookupBankID(ctx, "gb", "400300"); resp == nil {
+		t.Errorf("Expired entry should be fetched from the API")
+	}
+	if calls != 2 {
+		t.Errorf("API called %d times, want 2", calls)
+	}
+
+	client.BankDirectory.SetCacheTTL(0)
+	client.BankDirectory.LookupBankID(ctx, "GB", "400300")
+	if calls != 3 {
+		t.Errorf("API called %d times, want 3", calls)
+	}
+}
+
+func TestBankDirectoryService_cacheReturnsCopies(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/v1/"+bankIDsPath, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSON(t, w, BankIDList{Data: []*BankIDData{expectedBank}})
+	})
+
+	client.BankDirectory.SetCacheTTL(time.Minute)
+	first, _, err := client.BankDirectory.LookupBankID(ctx, "GB", "400300")
+	if err != nil {
+		t.Fatalf("BankDirectory.LookupBankID returned error: %v", err)
+	}
+	first.Attributes.Name = "changed"
+	first.Attributes.Reachability[0].Reachable = false
+
+	cached, _, _ := client.BankDirectory.LookupBankID(ctx, "GB", "400300")
+	cached.Attributes.Name = "changed again"
+	again, _, _ := client.BankDirectory.LookupBankID(ctx, "GB", "400300")
+	if calls != 1 || !reflect.DeepEqual(again, expectedBank) {
+		t.Errorf("Cached bank is %+v after %d calls, want %+v", again.Attributes, calls, expectedBank.Attributes)
+	}
+}
+
+func TestBankDirectoryService_cacheSharedWithViews(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/v1/"+bankIDsPath, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSON(t, w, BankIDList{Data: []*BankIDData{expectedBank}})
+	})
+
+	view := client.ForOrganisation(testOrganisationID)
+	client.BankDirectory.SetCacheTTL(time.Minute)
+	client.BankDirectory.LookupBankID(ctx, "GB", "400300")
+	if _, resp, _ := view.BankDirectory.LookupBankID(ctx, "GB", "400300"); resp != nil || calls != 1 {
+		t.Errorf("View should be served from the cache enabled on its parent, API called %d times", calls)
+	}
+}
+
+func TestTTLCache_sweepsExpiredEntries(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := newTTLCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.store("a", 1)
+	now = now.Add(30 * time.Second)
+	c.store("b", 2)
+	now = now.Add(45 * time.Second)
+	c.store("c", 3)
+
+	if _, ok := c.entries["a"]; ok || len(c.entries) != 2 {
+		t.Errorf("Entries after sweep are %v, want b and c", c.entries)
+	}
+	var v int
+	if !c.load("b", &v) || v != 2 {
+		t.Errorf("load(b) = %v, want 2", v)
+	}
+}
@@ -0,0 +1,306 @@
+package form3
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is wrapped by the error returned for requests rejected by an open circuit, see CircuitOpenError.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// CircuitOpenError is returned, wrapped in a *url.Error, by Client.Do when the circuit of a request is open.
+type CircuitOpenError struct {
+	// Circuit is the key of the open circuit, e.g. the host of the API.
+	Circuit string
+	// RetryAt is when the circuit lets a probe request through again.
+	RetryAt time.Time
+}
+
+// Error returns a string representation of the rejection.
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%v %q until %s", ErrCircuitOpen, e.Circuit, e.RetryAt.Format(time.RFC3339))
+}
+
+// Unwrap returns ErrCircuitOpen.
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+// CircuitState is the state of a circuit.
+type CircuitState int
+
+// States of a circuit.
+const (
+	// CircuitClosed lets all requests through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects all requests.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through to test recovery.
+	CircuitHalfOpen
+)
+
+// String returns the name of s.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitPerHost groups requests into one circuit per API host.
+func CircuitPerHost(req *http.Request) string {
+	return req.URL.Host
+}
+
+// CircuitPerEndpoint groups requests into one circuit per method and path, with
+// UUID path segments replaced by ":id" so that all resources of a kind share a circuit.
+func CircuitPerEndpoint(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, segment := range segments {
+		if UUID(segment).Validate() == nil {
+			segments[i] = ":id"
+		}
+	}
+	return req.Method + " " + req.URL.Host + strings.Join(segments, "/")
+}
+
+// CircuitBreakerOptions configures a CircuitBreaker. Zero values select the defaults.
+type CircuitBreakerOptions struct {
+	// Circuit returns the circuit a request belongs to. Defaults to CircuitPerHost.
+	Circuit func(req *http.Request) string
+	// FailureRatio of requests in a window that opens the circuit. Defaults to 0.5.
+	FailureRatio float64
+	// MinRequests in a window before the failure ratio is considered. Defaults to 10.
+	MinRequests int
+	// Window over which requests are counted while the circuit is closed. Defaults to 1 minute.
+	Window time.Duration
+	// OpenDuration for which an open circuit rejects requests before probing. Defaults to 30 seconds.
+	OpenDuration time.Duration
+	// HalfOpenProbes is the number of probe requests let through, and required to succeed
+	// to close the circuit, while it is half-open. Defaults to 1.
+	HalfOpenProbes int
+	// IsFailure reports whether the outcome of a request counts as a failure.
+	// Defaults to transport errors and 5xx responses. Requests that fail because their
+	// context was canceled or timed out are not counted at all.
+	IsFailure func(resp *http.Response, err error) bool
+}
+
+// CircuitBreaker stops sending requests to an API that keeps failing. Install it with
+// Client.SetCircuitBreaker; it is safe for concurrent use and may be shared by several clients.
+type CircuitBreaker struct {
+	opts CircuitBreakerOptions
+	now  func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// circuit holds the state and counters of a single circuit.
+type circuit struct {
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int
+	successes   int
+}
+
+// NewCircuitBreaker returns a CircuitBreaker. If opts is nil, the defaults are used.
+func NewCircuitBreaker(opts *CircuitBreakerOptions) *CircuitBreaker {
+	b := &CircuitBreaker{now: time.Now, circuits: make(map[string]*circuit)}
+	if opts != nil {
+		b.opts = *opts
+	}
+	if b.opts.Circuit == nil {
+		b.opts.Circuit = CircuitPerHost
+	}
+	if b.opts.FailureRatio <= 0 {
+		b.opts.FailureRatio = 0.5
+	}
+	if b.opts.MinRequests <= 0 {
+		b.opts.MinRequests = 10
+	}
+	if b.opts.Window <= 0 {
+		b.opts.Window = time.Minute
+	}
+	if b.opts.OpenDuration <= 0 {
+		b.opts.OpenDuration = 30 * time.Second
+	}
+	if b.opts.HalfOpenProbes <= 0 {
+		b.opts.HalfOpenProbes = 1
+	}
+	if b.opts.IsFailure == nil {
+		b.opts.IsFailure = func(resp *http.Response, err error) bool {
+			return err != nil || resp.StatusCode >= 500
+		}
+	}
+	return b
+}
+
+// State returns the state of the circuit with the given key. Unknown circuits are closed.
+func (b *CircuitBreaker) State(key string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[key]
+	if !ok {
+		return CircuitClosed
+	}
+	return b.current(c)
+}
+
+// States returns the states of all circuits that have seen requests, e.g. for a health check.
+func (b *CircuitBreaker) States() map[string]CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states := make(map[string]CircuitState, len(b.circuits))
+	for key, c := range b.circuits {
+		states[key] = b.current(c)
+	}
+	return states
+}
+
+// current returns the state of c, moving it from open to half-open once its open duration has passed.
+func (b *CircuitBreaker) current(c *circuit) CircuitState {
+	if c.state == CircuitOpen && !b.now().Before(c.openedAt.Add(b.opts.OpenDuration)) {
+		c.state = CircuitHalfOpen
+		c.probes, c.successes = 0, 0
+	}
+	return c.state
+}
+
+// allow reports whether a request to the circuit key may be sent, and whether it is a probe.
+func (b *CircuitBreaker) allow(key string) (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[key]
+	if !ok {
+		c = &circuit{windowStart: b.now()}
+		b.circuits[key] = c
+	}
+
+	switch b.current(c) {
+	case CircuitOpen:
+		return false, &CircuitOpenError{Circuit: key, RetryAt: c.openedAt.Add(b.opts.OpenDuration)}
+	case CircuitHalfOpen:
+		if c.probes >= b.opts.HalfOpenProbes {
+			return false, &CircuitOpenError{Circuit: key, RetryAt: b.now()}
+		}
+		c.probes++
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// record updates the circuit key with the outcome of a request let through by allow.
+func (b *CircuitBreaker) record(key string, probe bool, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuits[key]
+	now := b.now()
+	switch {
+	case probe && c.state == CircuitHalfOpen:
+		if failed {
+			c.state, c.openedAt = CircuitOpen, now
+			return
+		}
+		c.successes++
+		if c.successes >= b.opts.HalfOpenProbes {
+			*c = circuit{state: CircuitClosed, windowStart: now}
+		}
+	case !probe && c.state == CircuitClosed:
+		if now.Sub(c.windowStart) >= b.opts.Window {
+			c.windowStart, c.requests, c.failures = now, 0, 0
+		}
+		c.requests++
+		if failed {
+			c.failures++
+		}
+		if c.requests >= b.opts.MinRequests && float64(c.failures)/float64(c.requests) >= b.opts.FailureRatio {
+			c.state, c.openedAt = CircuitOpen, now
+		}
+	}
+}
+
+// cancel gives back the probe slot of a request let through by allow whose outcome is not recorded.
+func (b *CircuitBreaker) cancel(key string, probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c := b.circuits[key]; probe && c.state == CircuitHalfOpen && c.probes > 0 {
+		c.probes--
+	}
+}
+
+// SetCircuitBreaker makes the client send requests through breaker, which rejects them with an
+// error wrapping ErrCircuitOpen while their circuit is open. Responses served from the cache, see
+// SetCache, do not pass the breaker. If breaker is nil, the circuit breaker is removed.
+// Views returned by ForOrganisation before the call are not affected.
+func (c *Client) SetCircuitBreaker(breaker *CircuitBreaker) {
+	wrap := func(base http.RoundTripper) http.RoundTripper {
+		if t, ok := base.(*breakerTransport); ok {
+			base = t.base
+		}
+		if breaker == nil {
+			return base
+		}
+		return &breakerTransport{base: base, breaker: breaker}
+	}
+
+	hc := *c.httpClient
+	if t, ok := hc.Transport.(*cacheTransport); ok {
+		cache := *t
+		cache.base = wrap(t.base)
+		hc.Transport = &cache
+	} else {
+		hc.Transport = wrap(hc.Transport)
+	}
+	c.httpClient = &hc
+}
+
+// breakerTransport is an http.RoundTripper guarded by a CircuitBreaker.
+type breakerTransport struct {
+	base    http.RoundTripper
+	breaker *CircuitBreaker
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	key := t.breaker.opts.Circuit(req)
+	probe, err := t.breaker.allow(key)
+	if err != nil {
+		// A RoundTripper must close the request body, even when the request is not sent.
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil && req.Context().Err() != nil {
+		// The caller gave up on the request, which says nothing about the health of the API.
+		t.breaker.cancel(key, probe)
+		return resp, err
+	}
+	t.breaker.record(key, probe, t.breaker.opts.IsFailure(resp, err))
+	return resp, err
+}
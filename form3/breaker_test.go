@@ -0,0 +1,154 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	setup()
+	defer teardown()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker(&CircuitBreakerOptions{MinRequests: 4, FailureRatio: 0.5, OpenDuration: time.Minute})
+	breaker.now = func() time.Time { return now }
+	client.SetCircuitBreaker(breaker)
+
+	failing := true
+	requests := 0
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if failing {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		writeJSON(t, w, expectedAccount)
+	})
+	host := client.baseURL.Host
+
+	for i := 0; i < 4; i++ {
+		if _, _, err := client.Accounts.Fetch(ctx, testAccountID); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Request %d was rejected before the circuit opened", i)
+		}
+	}
+	if state := breaker.State(host); state != CircuitOpen {
+		t.Fatalf("Circuit is %v after failures, want open", state)
+	}
+
+	_, _, err := client.Accounts.Fetch(ctx, testAccountID)
+	var openErr *CircuitOpenError
+	if !errors.Is(err, ErrCircuitOpen) || !errors.As(err, &openErr) || openErr.Circuit != host {
+		t.Errorf("Accounts.Fetch returned %v, want CircuitOpenError", err)
+	}
+	if requests != 4 {
+		t.Errorf("Open circuit sent %d requests, want 4", requests)
+	}
+
+	now = now.Add(time.Minute)
+	if state := breaker.State(host); state != CircuitHalfOpen {
+		t.Fatalf("Circuit is %v after the open duration, want half-open", state)
+	}
+	failing = false
+	if _, _, err := client.Accounts.Fetch(ctx, testAccountID); err != nil {
+		t.Fatalf("Probe returned error: %v", err)
+	}
+	if states := breaker.States(); states[host] != CircuitClosed {
+		t.Errorf("Circuits are %v after a successful probe, want closed", states)
+	}
+}
+
+func TestCircuitBreaker_failedProbeReopens(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(&CircuitBreakerOptions{MinRequests: 1, OpenDuration: time.Second})
+	breaker.now = func() time.Time { return now }
+
+	if _, err := breaker.allow("c"); err != nil {
+		t.Fatalf("allow returned error: %v", err)
+	}
+	breaker.record("c", false, true)
+	if state := breaker.State("c"); state != CircuitOpen {
+		t.Fatalf("Circuit is %v, want open", state)
+	}
+	now = now.Add(time.Second)
+	probe, err := breaker.allow("c")
+	if err != nil || !probe {
+		t.Fatalf("allow returned %v, %v, want a probe", probe, err)
+	}
+	if _, err := breaker.allow("c"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Second probe was allowed while the first is in flight")
+	}
+	breaker.record("c", true, true)
+	if state := breaker.State("c"); state != CircuitOpen {
+		t.Errorf("Circuit is %v after a failed probe, want open", state)
+	}
+}
+
+func TestCircuitBreaker_ignoresCanceledRequests(t *testing.T) {
+	setup()
+	defer teardown()
+
+	breaker := NewCircuitBreaker(&CircuitBreakerOptions{MinRequests: 2, FailureRatio: 0.5, OpenDuration: time.Minute})
+	client.SetCircuitBreaker(breaker)
+
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	for i := 0; i < 3; i++ {
+		c, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		_, _, err := client.Accounts.Fetch(c, testAccountID)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Accounts.Fetch returned %v, want context.DeadlineExceeded", err)
+		}
+	}
+	if state := breaker.State(client.baseURL.Host); state != CircuitClosed {
+		t.Errorf("Circuit is %v after canceled requests, want closed", state)
+	}
+}
+
+// trackedBody is a request body that records whether it was closed.
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestCircuitBreaker_closesRejectedRequestBody(t *testing.T) {
+	body := &trackedBody{Reader: strings.NewReader(`{"data":{}}`)}
+	req := httptest.NewRequest(http.MethodPost, "http://localhost/v1/"+accountsPath, body)
+	req.Body = body
+
+	breaker := NewCircuitBreaker(&CircuitBreakerOptions{MinRequests: 1, OpenDuration: time.Minute})
+	key := breaker.opts.Circuit(req)
+	if _, err := breaker.allow(key); err != nil {
+		t.Fatalf("allow returned error: %v", err)
+	}
+	breaker.record(key, false, true)
+
+	if _, err := (&breakerTransport{breaker: breaker}).RoundTrip(req); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("RoundTrip returned %v, want ErrCircuitOpen", err)
+	}
+	if !body.closed {
+		t.Errorf("Rejected request body was not closed")
+	}
+}
+
+func TestCircuitPerEndpoint(t *testing.T) {
+	u, _ := url.Parse("http://localhost/v1/" + accountsPath + "/" + testAccountID + "?version=1")
+	got := CircuitPerEndpoint(&http.Request{Method: http.MethodDelete, URL: u})
+	if want := "DELETE localhost/v1/" + accountsPath + "/:id"; got != want {
+		t.Errorf("CircuitPerEndpoint is %q, want %q", got, want)
+	}
+}
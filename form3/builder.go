@@ -0,0 +1,298 @@
+package form3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidAccount is wrapped by the error AccountBuilder.Build returns for an incomplete or malformed account.
+var ErrInvalidAccount = errors.New("invalid account")
+
+var bicPattern = regexp.MustCompile(`^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+
+// schemePreset holds the defaults and format rules of the accounts of a country.
+type schemePreset struct {
+	country      string
+	currency     string
+	bankIDCode   string
+	bankID       *regexp.Regexp
+	bankIDFormat string
+	bicRequired  bool
+	account      *regexp.Regexp
+	accountFmt   string
+}
+
+// Presets of the schemes supported by AccountBuilder, by country.
+var schemePresets = map[string]*schemePreset{
+	"GB": {
+		country: "GB", currency: "GBP", bankIDCode: "GBDSC",
+		bankID: regexp.MustCompile(`^\d{6}$`), bankIDFormat: "6 digits", bicRequired: true,
+		account: regexp.MustCompile(`^\d{8}$`), accountFmt: "8 digits",
+	},
+	"DE": {
+		country: "DE", currency: "EUR", bankIDCode: "DEBLZ",
+		bankID: regexp.MustCompile(`^\d{8}$`), bankIDFormat: "8 digits",
+		account: regexp.MustCompile(`^\d{7,10}$`), accountFmt: "7 to 10 digits",
+	},
+	"FR": {
+		country: "FR", currency: "EUR", bankIDCode: "FR",
+		bankID: regexp.MustCompile(`^\d{10}$`), bankIDFormat: "10 digits",
+		account: regexp.MustCompile(`^[0-9A-Z]{10}\d{0,2}$`), accountFmt: "10 characters and an optional 2 digit check",
+	},
+	"AU": {
+		country: "AU", currency: "AUD", bankIDCode: "AUBSB",
+		bankID: regexp.MustCompile(`^\d{6}$`), bankIDFormat: "6 digits", bicRequired: true,
+		account: regexp.MustCompile(`^\d{6,10}$`), accountFmt: "6 to 10 digits",
+	},
+	"US": {
+		country: "US", currency: "USD", bankIDCode: "USABA",
+		bankID: regexp.MustCompile(`^\d{9}$`), bankIDFormat: "9 digits", bicRequired: true,
+		account: regexp.MustCompile(`^\d{6,17}$`), accountFmt: "6 to 17 digits",
+	},
+}
+
+// AccountBuilder builds an Account step by step. Use a scheme preset to fill in the
+// country, currency and bank ID code, then set the remaining attributes:
+//
+//	account, err := form3.NewAccountBuilder().
+//		UK().
+//		SortCode("400300").
+//		BIC("NWBKGB22").
+//		AccountNumber("41426819").
+//		Name("Samantha Holder").
+//		Build()
+//
+// Build validates the account against the rules of its country.
+type AccountBuilder struct {
+	data *AccountData
+}
+
+// NewAccountBuilder returns an AccountBuilder for a new account.
+func NewAccountBuilder() *AccountBuilder {
+	return &AccountBuilder{data: &AccountData{Type: accountsType, Attributes: &AccountAttributes{}}}
+}
+
+// preset applies the defaults of the scheme of country.
+func (b *AccountBuilder) preset(country string) *AccountBuilder {
+	p := schemePresets[country]
+	b.data.Attributes.Country = p.country
+	b.data.Attributes.BaseCurrency = p.currency
+	b.data.Attributes.BankIDCode = p.bankIDCode
+	return b
+}
+
+// UK presets a United Kingdom account: GB, GBP and sort codes (GBDSC).
+func (b *AccountBuilder) UK() *AccountBuilder { return b.preset("GB") }
+
+// Germany presets a German account: DE, EUR and Bankleitzahl (DEBLZ).
+func (b *AccountBuilder) Germany() *AccountBuilder { return b.preset("DE") }
+
+// France presets a French account: FR, EUR and French bank codes (FR).
+func (b *AccountBuilder) France() *AccountBuilder { return b.preset("FR") }
+
+// Australia presets an Australian account: AU, AUD and BSB codes (AUBSB).
+func (b *AccountBuilder) Australia() *AccountBuilder { return b.preset("AU") }
+
+// US presets a United States account: US, USD and ABA routing numbers (USABA).
+func (b *AccountBuilder) US() *AccountBuilder { return b.preset("US") }
+
+// Country sets the ISO 3166-1 country code, for countries without a preset.
+func (b *AccountBuilder) Country(country string) *AccountBuilder {
+	b.data.Attributes.Country = country
+	return b
+}
+
+// BaseCurrency sets the ISO 4217 currency code.
+func (b *AccountBuilder) BaseCurrency(currency string) *AccountBuilder {
+	b.data.Attributes.BaseCurrency = currency
+	return b
+}
+
+// ID sets the account ID. If it is not set, Build generates a random one.
+func (b *AccountBuilder) ID(id UUID) *AccountBuilder {
+	b.data.ID = id
+	return b
+}
+
+// OrganisationID sets the organisation owning the account.
+func (b *AccountBuilder) OrganisationID(id UUID) *AccountBuilder {
+	b.data.OrganisationID = id
+	return b
+}
+
+// BankID sets the local bank identifier.
+func (b *AccountBuilder) BankID(bankID string) *AccountBuilder {
+	b.data.Attributes.BankID = bankID
+	return b
+}
+
+// SortCode sets the bank identifier of a UK account. Dashes and spaces, as in "40-03-00", are removed.
+func (b *AccountBuilder) SortCode(sortCode string) *AccountBuilder {
+	return b.BankID(strings.NewReplacer("-", "", " ", "").Replace(sortCode))
+}
+
+// BankIDCode sets the type of the bank identifier.
+func (b *AccountBuilder) BankIDCode(code string) *AccountBuilder {
+	b.data.Attributes.BankIDCode = code
+	return b
+}
+
+// BIC sets the SWIFT BIC of the bank.
+func (b *AccountBuilder) BIC(bic string) *AccountBuilder {
+	b.data.Attributes.Bic = bic
+	return b
+}
+
+// AccountNumber sets the account number. If it is not set, Form3 generates one.
+func (b *AccountBuilder) AccountNumber(number string) *AccountBuilder {
+	b.data.Attributes.AccountNumber = number
+	return b
+}
+
+// IBAN sets the IBAN. If it is not set, Form3 generates one where supported.
+func (b *AccountBuilder) IBAN(iban string) *AccountBuilder {
+	b.data.Attributes.Iban = strings.ReplaceAll(iban, " ", "")
+	return b
+}
+
+// Name sets the names of the account holder, up to four lines.
+func (b *AccountBuilder) Name(names ...string) *AccountBuilder {
+	b.data.Attributes.Name = names
+	return b
+}
+
+// AlternativeNames sets the alternative names of the account holder, up to three.
+func (b *AccountBuilder) AlternativeNames(names ...string) *AccountBuilder {
+	b.data.Attributes.AlternativeNames = names
+	return b
+}
+
+// CustomerID sets a customer reference of the account holder.
+func (b *AccountBuilder) CustomerID(id string) *AccountBuilder {
+	b.data.Attributes.CustomerID = id
+	return b
+}
+
+// Classification sets the account classification, AccountTypePersonal or AccountTypeBusiness.
+func (b *AccountBuilder) Classification(classification string) *AccountBuilder {
+	b.data.Attributes.AccountClassification = classification
+	return b
+}
+
+// JointAccount marks the account as held by more than one person.
+func (b *AccountBuilder) JointAccount(joint bool) *AccountBuilder {
+	b.data.Attributes.JointAccount = joint
+	return b
+}
+
+// SecondaryIdentification sets the secondary identification, e.g. a building society roll number.
+func (b *AccountBuilder) SecondaryIdentification(id string) *AccountBuilder {
+	b.data.Attributes.SecondaryIdentification = id
+	return b
+}
+
+// Build validates the account and returns it. Type is set to "accounts" and, if no ID was set,
+// a random one is generated. An error wrapping ErrInvalidAccount lists all problems found.
+// The returned account is a copy, so the builder can be changed and built again, e.g. to build
+// several accounts that differ only in a few attributes, without affecting accounts already built.
+func (b *AccountBuilder) Build() (*Account, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	data := b.data.clone()
+	if data.ID == "" {
+		data.ID = NewUUID()
+	}
+	return &Account{Data: data}, nil
+}
+
+// clone returns a deep copy of d.
+func (d *AccountData) clone() *AccountData {
+	c := *d
+	if d.Attributes != nil {
+		attributes := *d.Attributes
+		attributes.Name = append([]string(nil), d.Attributes.Name...)
+		attributes.AlternativeNames = append([]string(nil), d.Attributes.AlternativeNames...)
+		attributes.Extra = cloneRaw(d.Attributes.Extra)
+		c.Attributes = &attributes
+	}
+	if d.Relationships != nil {
+		c.Relationships = make(Relationships, len(d.Relationships))
+		for k, r := range d.Relationships {
+			if r != nil {
+				r = &Relationship{
+					Data:  append(json.RawMessage(nil), r.Data...),
+					Links: append(json.RawMessage(nil), r.Links...),
+					Meta:  append(json.RawMessage(nil), r.Meta...),
+				}
+			}
+			c.Relationships[k] = r
+		}
+	}
+	c.Extra = cloneRaw(d.Extra)
+	return &c
+}
+
+// cloneRaw returns a deep copy of m.
+func cloneRaw(m map[string]json.RawMessage) map[string]json.RawMessage {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]json.RawMessage, len(m))
+	for k, v := range m {
+		c[k] = append(json.RawMessage(nil), v...)
+	}
+	return c
+}
+
+// validate checks the account against the rules of its country.
+func (b *AccountBuilder) validate() error {
+	a := b.data.Attributes
+	var problems []string
+
+	if b.data.ID != "" {
+		if err := b.data.ID.Validate(); err != nil {
+			problems = append(problems, "id is not a valid UUID")
+		}
+	}
+	if len(a.Country) != 2 {
+		problems = append(problems, "country must be a 2 letter ISO code")
+	}
+	if len(a.Name) == 0 || len(a.Name) > 4 {
+		problems = append(problems, "name must have 1 to 4 lines")
+	}
+	if len(a.AlternativeNames) > 3 {
+		problems = append(problems, "alternative_names must have at most 3 entries")
+	}
+	if a.Bic != "" && !bicPattern.MatchString(a.Bic) {
+		problems = append(problems, "bic must be 8 or 11 characters")
+	}
+	if c := a.AccountClassification; c != "" && c != AccountTypePersonal && c != AccountTypeBusiness {
+		problems = append(problems, fmt.Sprintf("account_classification must be %s or %s", AccountTypePersonal, AccountTypeBusiness))
+	}
+
+	if p, ok := schemePresets[a.Country]; ok {
+		if a.BankID == "" {
+			problems = append(problems, "bank_id is required")
+		} else if !p.bankID.MatchString(a.BankID) {
+			problems = append(problems, "bank_id must be "+p.bankIDFormat)
+		}
+		if a.BankIDCode != p.bankIDCode {
+			problems = append(problems, "bank_id_code must be "+p.bankIDCode)
+		}
+		if p.bicRequired && a.Bic == "" {
+			problems = append(problems, "bic is required")
+		}
+		if a.AccountNumber != "" && !p.account.MatchString(a.AccountNumber) {
+			problems = append(problems, "account_number must be "+p.accountFmt)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidAccount, strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package form3
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAccountBuilder_UK(t *testing.T) {
+	account, err := NewAccountBuilder().
+		UK().
+		SortCode("40-03-00").
+		BIC("NWBKGB22").
+		AccountNumber("41426819").
+		Name("Samantha Holder").
+		Classification(AccountTypePersonal).
+		Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	a := account.Data.Attributes
+	if account.Data.Type != "accounts" || account.Data.ID.Validate() != nil {
+		t.Errorf("Build returned type %q and ID %q", account.Data.Type, account.Data.ID)
+	}
+	if a.Country != "GB" || a.BaseCurrency != "GBP" || a.BankIDCode != "GBDSC" || a.BankID != "400300" {
+		t.Errorf("Build returned attributes %+v", a)
+	}
+}
+
+func TestAccountBuilder_keepsID(t *testing.T) {
+	account, err := NewAccountBuilder().Germany().BankID("37040044").AccountNumber("0532013000").Name("Max").ID(testAccountID).Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if account.Data.ID != testAccountID {
+		t.Errorf("Build returned ID %q, want %q", account.Data.ID, testAccountID)
+	}
+}
+
+func TestAccountBuilder_validates(t *testing.T) {
+	_, err := NewAccountBuilder().UK().SortCode("4003").AccountNumber("123").Build()
+	if !errors.Is(err, ErrInvalidAccount) {
+		t.Fatalf("Build returned %v, want ErrInvalidAccount", err)
+	}
+	for _, problem := range []string{"name", "bank_id must be 6 digits", "bic is required", "account_number must be 8 digits"} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("Build error %q does not mention %q", err, problem)
+		}
+	}
+}
+
+func TestAccountBuilder_reusable(t *testing.T) {
+	b := NewAccountBuilder().UK().SortCode("400300").BIC("NWBKGB22").Name("Samantha Holder")
+	first, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	first.Data.Attributes.Name[0] = "Changed"
+	second, err := b.AlternativeNames("Sam Holder").Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if got := second.Data.Attributes.Name; !reflect.DeepEqual(got, []string{"Samantha Holder"}) {
+		t.Errorf("Build returned name %v after the first account was changed", got)
+	}
+	if first.Data.Attributes.AlternativeNames != nil {
+		t.Errorf("Setter changed the built account's alternative names to %v", first.Data.Attributes.AlternativeNames)
+	}
+	if first.Data.ID == second.Data.ID {
+		t.Errorf("Build returned the generated ID %q twice", first.Data.ID)
+	}
+}
+
+func TestAccountBuilder_expectedAccount(t *testing.T) {
+	account, err := NewAccountBuilder().
+		UK().
+		ID(testAccountID).
+		OrganisationID(testOrganisationID).
+		SortCode("400300").
+		BIC("NWBKGB22").
+		Name("Samantha Holder").
+		AlternativeNames("Sam Holder").
+		Classification(AccountTypePersonal).
+		SecondaryIdentification("A1B2C3D4").
+		Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if !reflect.DeepEqual(account, expectedAccount) {
+		t.Errorf("Build returned %+v, expected %+v", account.Data, expectedAccount.Data)
+	}
+}
@@ -0,0 +1,310 @@
+package form3
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheHeader is set to "1" on responses whose body was served from the cache, see FromCache.
+const CacheHeader = "X-From-Cache"
+
+// maxCachedBodyBytes limits the size of response bodies that are cached.
+const maxCachedBodyBytes = 1 << 20
+
+// CachedResponse is a successful GET response kept by a CacheStore.
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// StoredAt is when the response was stored or last revalidated.
+	StoredAt time.Time
+}
+
+// CacheStore stores cached responses by a key derived from the request URL and the Accept and
+// Authorization request headers. Implementations must be safe for concurrent use.
+type CacheStore interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, response *CachedResponse)
+	Delete(key string)
+}
+
+// FromCache reports whether the body of resp was served from the cache,
+// either without contacting the API or after the API confirmed it was not modified.
+func FromCache(resp *http.Response) bool {
+	return resp != nil && resp.Header.Get(CacheHeader) == "1"
+}
+
+// SetCache makes the client cache GET responses in store. Responses carrying an ETag are
+// revalidated with If-None-Match; responses with a Cache-Control max-age are served without
+// contacting the API until they expire. Only JSON responses of up to 1 MiB to requests accepting
+// JSON are cached, so downloads such as ReportsService.Download are streamed uncached. Responses
+// marked no-store, or varying on request headers other than Accept and Authorization, are never cached.
+// A successful PATCH, PUT or DELETE of a resource through the client removes it from the cache;
+// cached pages of lists are only refreshed by revalidation or expiry.
+// If store is nil, caching is disabled. Views returned by ForOrganisation before the call are not affected.
+// SetCache must be called before the client is used, it is not safe to call concurrently with requests.
+func (c *Client) SetCache(store CacheStore) {
+	hc := *c.httpClient
+	base := hc.Transport
+	if t, ok := base.(*cacheTransport); ok {
+		base = t.base
+	}
+	if store == nil {
+		hc.Transport = base
+	} else {
+		hc.Transport = &cacheTransport{base: base, store: store, now: time.Now}
+	}
+	c.httpClient = &hc
+}
+
+// cacheTransport is an http.RoundTripper serving GET requests from a CacheStore.
+type cacheTransport struct {
+	base  http.RoundTripper
+	store CacheStore
+	now   func() time.Time
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if req.Method != http.MethodGet {
+		resp, err := base.RoundTrip(req)
+		if err == nil && resp.StatusCode < 300 && req.Method != http.MethodPost {
+			u := *req.URL
+			u.RawQuery = ""
+			t.store.Delete(cacheKey(&u, req.Header))
+		}
+		return resp, err
+	}
+	if !isJSONContentType(req.Header.Get("Accept")) {
+		return base.RoundTrip(req)
+	}
+	key := cacheKey(req.URL, req.Header)
+
+	cached, ok := t.store.Get(key)
+	if ok && t.fresh(cached) {
+		return cachedResponse(req, cached), nil
+	}
+	if ok {
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req = req.Clone(req.Context())
+			req.Header.Set("If-None-Match", etag)
+		}
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		_ = resp.Body.Close()
+		revalidated := *cached
+		revalidated.Header = cached.Header.Clone()
+		for name, values := range resp.Header {
+			if name == "Cache-Control" || name == "Etag" || name == "Expires" || name == "Date" {
+				revalidated.Header[name] = values
+			}
+		}
+		revalidated.StoredAt = t.now()
+		t.store.Set(key, &revalidated)
+		return cachedResponse(req, &revalidated), nil
+	}
+
+	if resp.StatusCode != http.StatusOK || !cacheable(resp) {
+		if ok {
+			t.store.Delete(key)
+		}
+		return resp, nil
+	}
+
+	// Read one byte more than the limit to tell whether the body exceeds it.
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxCachedBodyBytes+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBodyBytes {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	t.store.Set(key, &CachedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		StoredAt:   t.now(),
+	})
+	return resp, nil
+}
+
+// fresh reports whether cached can be served without revalidation.
+func (t *cacheTransport) fresh(cached *CachedResponse) bool {
+	directives := cacheControl(cached.Header)
+	if _, noCache := directives["no-cache"]; noCache {
+		return false
+	}
+	maxAge, err := strconv.Atoi(directives["max-age"])
+	if err != nil || maxAge <= 0 {
+		return false
+	}
+	return t.now().Before(cached.StoredAt.Add(time.Duration(maxAge) * time.Second))
+}
+
+// cacheable reports whether resp may be stored.
+func cacheable(resp *http.Response) bool {
+	if !isJSONContentType(resp.Header.Get("Content-Type")) || resp.ContentLength > maxCachedBodyBytes {
+		return false
+	}
+	for _, value := range resp.Header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			switch http.CanonicalHeaderKey(strings.TrimSpace(name)) {
+			case "Accept", "Authorization", "Accept-Encoding", "":
+			default:
+				return false
+			}
+		}
+	}
+	directives := cacheControl(resp.Header)
+	if _, noStore := directives["no-store"]; noStore {
+		return false
+	}
+	maxAge, _ := strconv.Atoi(directives["max-age"])
+	return resp.Header.Get("ETag") != "" || maxAge > 0
+}
+
+// cacheKey returns the key responses to a request for u with header are stored under.
+// It includes the Accept header and a hash of the Authorization header, so responses are
+// neither served for another representation nor to other credentials.
+func cacheKey(u *url.URL, header http.Header) string {
+	key := u.String() + " " + header.Get("Accept")
+	if auth := header.Get("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		key += " " + hex.EncodeToString(sum[:])
+	}
+	return key
+}
+
+// cacheControl parses the Cache-Control directives of header, e.g. {"max-age": "60", "no-cache": ""}.
+func cacheControl(header http.Header) map[string]string {
+	directives := make(map[string]string)
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, arg := strings.TrimSpace(directive), ""
+			if i := strings.IndexByte(name, '='); i >= 0 {
+				name, arg = name[:i], strings.Trim(name[i+1:], `"`)
+			}
+			if name != "" {
+				directives[strings.ToLower(name)] = arg
+			}
+		}
+	}
+	return directives
+}
+
+// cachedResponse builds the response to req from cached.
+func cachedResponse(req *http.Request, cached *CachedResponse) *http.Response {
+	header := cached.Header.Clone()
+	header.Set(CacheHeader, "1")
+	return &http.Response{
+		Status:        strconv.Itoa(cached.StatusCode) + " " + http.StatusText(cached.StatusCode),
+		StatusCode:    cached.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	}
+}
+
+// LRUCache is an in-memory CacheStore that keeps at most a fixed number of responses,
+// evicting the least recently used one when full.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key      string
+	response *CachedResponse
+}
+
+// NewLRUCache returns an LRUCache holding up to capacity responses.
+// If capacity is not positive, a default of 1000 is used.
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+	return &LRUCache{capacity: capacity, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Get returns the response stored under key.
+func (c *LRUCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry).response, true
+}
+
+// Set stores response under key.
+func (c *LRUCache) Set(key string, response *CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry).response = response
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, response: response})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete removes the response stored under key.
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.order.Remove(e)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of stored responses.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,194 @@
+package form3
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_SetCache(t *testing.T) {
+	setup()
+	defer teardown()
+	client.SetCache(NewLRUCache(10))
+
+	requests := 0
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			writeJSON(t, w, expectedAccount)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	account, resp, err := client.Accounts.Fetch(ctx, testAccountID)
+	if err != nil || FromCache(resp) {
+		t.Fatalf("First Accounts.Fetch returned %v, from cache %v", err, FromCache(resp))
+	}
+	cached, resp, err := client.Accounts.Fetch(ctx, testAccountID)
+	if err != nil || !FromCache(resp) {
+		t.Fatalf("Second Accounts.Fetch returned %v, from cache %v", err, FromCache(resp))
+	}
+	if cached.Data.ID != account.Data.ID || requests != 2 {
+		t.Errorf("Revalidated Accounts.Fetch returned %+v after %d requests", cached.Data, requests)
+	}
+
+	if _, err := client.Accounts.Delete(ctx, testAccountID, 0); err != nil {
+		t.Fatalf("Accounts.Delete returned error: %v", err)
+	}
+	if _, resp, _ := client.Accounts.Fetch(ctx, testAccountID); FromCache(resp) {
+		t.Errorf("Accounts.Fetch after Delete was served from cache")
+	}
+}
+
+func TestCacheTransport_maxAge(t *testing.T) {
+	setup()
+	defer teardown()
+	client.SetCache(NewLRUCache(10))
+	now := time.Now()
+	client.httpClient.Transport.(*cacheTransport).now = func() time.Time { return now }
+
+	requests := 0
+	mux.HandleFunc("/v1/"+accountsPath, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "private, max-age=60")
+		writeJSON(t, w, AccountList{Data: []*AccountData{expectedAccount.Data}})
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Accounts.List(ctx, 0, 10); err != nil {
+			t.Fatalf("Accounts.List returned error: %v", err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Fresh list was requested %d times, want 1", requests)
+	}
+
+	now = now.Add(time.Minute)
+	if _, resp, _ := client.Accounts.List(ctx, 0, 10); FromCache(resp) || requests != 2 {
+		t.Errorf("Expired list was served from cache after %d requests", requests)
+	}
+}
+
+func TestCacheTransport_noStore(t *testing.T) {
+	setup()
+	defer teardown()
+	cache := NewLRUCache(10)
+	client.SetCache(cache)
+
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "no-store")
+		writeJSON(t, w, expectedAccount)
+	})
+
+	if _, _, err := client.Accounts.Fetch(ctx, testAccountID); err != nil {
+		t.Fatalf("Accounts.Fetch returned error: %v", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("no-store response was cached")
+	}
+}
+
+func TestLRUCache_evicts(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Set("a", &CachedResponse{})
+	cache.Set("b", &CachedResponse{})
+	cache.Get("a")
+	cache.Set("c", &CachedResponse{})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("Least recently used entry was not evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Errorf("Recently used entry was evicted")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len is %d, want 2", cache.Len())
+	}
+}
+
+func TestCacheTransport_skipsDownloadsAndLargeBodies(t *testing.T) {
+	setup()
+	defer teardown()
+	cache := NewLRUCache(10)
+	client.SetCache(cache)
+
+	file := strings.Repeat("a", 2*maxCachedBodyBytes)
+	mux.HandleFunc("/v1/"+reportsPath+"/"+testReportID+"/"+reportFilePath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "text/csv")
+		fmt.Fprint(w, "id\n1\n")
+	})
+	mux.HandleFunc("/v1/"+accountsPath, func(w http.ResponseWriter, r *http.Request) {
+		// The body is streamed without a Content-Length, so its size is only known once read.
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", applicationJson)
+		fmt.Fprint(w, `{"data":[],"meta":"`+file+`"}`)
+	})
+
+	if _, err := client.Reports.Download(ctx, testReportID, new(bytes.Buffer)); err != nil {
+		t.Fatalf("Reports.Download returned error: %v", err)
+	}
+	request, _ := client.NewRequest(http.MethodGet, accountsPath, nil)
+	raw, _, err := client.DoRaw(ctx, request)
+	if err != nil {
+		t.Fatalf("DoRaw returned error: %v", err)
+	}
+	if len(raw) != len(file)+len(`{"data":[],"meta":""}`) {
+		t.Errorf("DoRaw returned %d bytes of a large body, want all of it", len(raw))
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Cache holds %d responses, want none", cache.Len())
+	}
+}
+
+func TestCacheTransport_varyAndAuthorization(t *testing.T) {
+	setup()
+	defer teardown()
+	cache := NewLRUCache(10)
+	client.SetCache(cache)
+
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		if r.URL.Query().Get("vary") != "" {
+			w.Header().Set("Vary", "Accept, Cookie")
+		}
+		writeJSON(t, w, expectedAccount)
+	})
+
+	fetch := func(path, auth string) *http.Response {
+		request, _ := client.NewRequest(http.MethodGet, path, nil)
+		if auth != "" {
+			request.Header.Set("Authorization", auth)
+		}
+		resp, err := client.Do(ctx, request, new(Account))
+		if err != nil {
+			t.Fatalf("Do returned error: %v", err)
+		}
+		return resp
+	}
+
+	path := accountsPath + "/" + testAccountID
+	fetch(path, "Bearer a")
+	if FromCache(fetch(path, "Bearer b")) {
+		t.Errorf("Response cached for one credential was served to another")
+	}
+	if !FromCache(fetch(path, "Bearer a")) {
+		t.Errorf("Response was not served from cache to the same credential")
+	}
+
+	fetch(path+"?vary=1", "")
+	if FromCache(fetch(path+"?vary=1", "")) {
+		t.Errorf("Response varying on Cookie was served from cache")
+	}
+}
@@ -0,0 +1,206 @@
+package form3
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// claimsPath URL path to claim resources.
+	claimsPath = "transaction/claims"
+)
+
+// ClaimReason is the reason code an indemnity claim is raised with.
+type ClaimReason string
+
+// Reasons an indemnity claim can be raised for, as defined by the Bacs DDICA scheme.
+const (
+	ClaimReasonNoMandate         ClaimReason = "1"
+	ClaimReasonMandateCancelled  ClaimReason = "2"
+	ClaimReasonAmountDiffers     ClaimReason = "3"
+	ClaimReasonDateDiffers       ClaimReason = "4"
+	ClaimReasonAdvanceNoticeNone ClaimReason = "5"
+	ClaimReasonMandateAltered    ClaimReason = "6"
+)
+
+// ClaimStatus is the processing status of a claim or a claim submission.
+type ClaimStatus string
+
+// Statuses of claims and their submissions.
+const (
+	ClaimStatusPending   ClaimStatus = "pending"
+	ClaimStatusAccepted  ClaimStatus = "accepted"
+	ClaimStatusConfirmed ClaimStatus = "confirmed"
+	ClaimStatusRejected  ClaimStatus = "rejected"
+	ClaimStatusFailed    ClaimStatus = "failed"
+)
+
+// Claim represents an indemnity claim raised against a collected direct debit.
+type Claim struct {
+	Data *ClaimData `json:"data"`
+}
+
+// ClaimList represents a list of indemnity claims.
+type ClaimList struct {
+	Data []*ClaimData `json:"data"`
+}
+
+// ClaimData represents the main attributes for a given claim.
+type ClaimData struct {
+	ResourceData
+	Attributes *ClaimAttributes `json:"attributes"`
+}
+
+// ClaimAttributes represents the available claim attribute fields.
+type ClaimAttributes struct {
+	Amount     string      `json:"amount"`
+	Currency   string      `json:"currency"`
+	Scheme     Scheme      `json:"scheme,omitempty"`
+	ReasonCode ClaimReason `json:"reason_code"`
+	// DirectDebitID is the ID of the disputed direct debit.
+	DirectDebitID    UUID   `json:"direct_debit_id,omitempty"`
+	MandateReference string `json:"mandate_reference,omitempty"`
+	// OriginalReference is the reference of the disputed collection.
+	OriginalReference string      `json:"original_reference,omitempty"`
+	ProcessingDate    string      `json:"processing_date,omitempty"`
+	DebtorParty       *Party      `json:"debtor_party,omitempty"`
+	BeneficiaryParty  *Party      `json:"beneficiary_party,omitempty"`
+	Status            ClaimStatus `json:"status,omitempty"`
+	StatusReason      string      `json:"status_reason,omitempty"`
+}
+
+// ClaimSubmission represents the submission of a claim to the scheme.
+type ClaimSubmission struct {
+	Data *ClaimSubmissionData `json:"data"`
+}
+
+// ClaimSubmissionData represents the main attributes for a given claim submission.
+type ClaimSubmissionData struct {
+	ResourceData
+	Attributes *ClaimSubmissionAttributes `json:"attributes,omitempty"`
+}
+
+// ClaimSubmissionAttributes represents the available claim submission attribute fields.
+type ClaimSubmissionAttributes struct {
+	Status             ClaimStatus `json:"status,omitempty"`
+	StatusReason       string      `json:"status_reason,omitempty"`
+	SchemeStatusCode   string      `json:"scheme_status_code,omitempty"`
+	SubmissionDateTime *Timestamp  `json:"submission_datetime,omitempty"`
+}
+
+// ClaimsService handles the communication with the indemnity claim related
+// methods of the Form3 API.
+//
+// Form3 API docs: https://api-docs.form3.tech/api.html?http#claims
+type ClaimsService service
+
+// Create raises a new claim.
+// If Client.GenerateIDs is set and the claim has no ID, a random one is assigned to claim before sending.
+func (s *ClaimsService) Create(ctx context.Context, claim *Claim) (*Claim, *http.Response, error) {
+	if claim != nil {
+		generateID(s.client, claim.Data)
+	}
+
+	request, err := s.client.NewRequest(http.MethodPost, claimsPath, claim)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := new(Claim)
+	resp, err := s.client.Do(ctx, request, c)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return c, resp, nil
+}
+
+// Fetch gets a single claim using the claim ID.
+func (s *ClaimsService) Fetch(ctx context.Context, claimID string) (*Claim, *http.Response, error) {
+	if err := validateIDs(claimID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(claimsPath, claimID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := new(Claim)
+	resp, err := s.client.Do(ctx, request, c)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return c, resp, nil
+}
+
+// List lists all claims. Supports pagination.
+func (s *ClaimsService) List(ctx context.Context, pageNumber int, pageSize int) (*ClaimList, *http.Response, error) {
+	path := s.client.listPath(claimsPath, pageNumber, pageSize)
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(ClaimList)
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, nil
+}
+
+// CreateSubmission submits a claim to the scheme.
+// If Client.GenerateIDs is set and the submission has no ID, a random one is assigned to submission before sending.
+func (s *ClaimsService) CreateSubmission(ctx context.Context, claimID string, submission *ClaimSubmission) (*ClaimSubmission, *http.Response, error) {
+	if err := validateIDs(claimID); err != nil {
+		return nil, nil, err
+	}
+	if submission != nil {
+		generateID(s.client, submission.Data)
+	}
+	path, err := joinPath(claimsPath, claimID, submissionsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodPost, path, submission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub := new(ClaimSubmission)
+	resp, err := s.client.Do(ctx, request, sub)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sub, resp, nil
+}
+
+// FetchSubmission gets a single submission of a claim, including its status.
+func (s *ClaimsService) FetchSubmission(ctx context.Context, claimID string, submissionID string) (*ClaimSubmission, *http.Response, error) {
+	if err := validateIDs(claimID, submissionID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(claimsPath, claimID, submissionsPath, submissionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub := new(ClaimSubmission)
+	resp, err := s.client.Do(ctx, request, sub)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sub, resp, nil
+}
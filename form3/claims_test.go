@@ -0,0 +1,143 @@
+package form3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testClaimID = "0a2c4e6a-8c0e-4a2c-9e6a-8c0e2a4c6e8a"
+
+var expectedClaim = &Claim{Data: &ClaimData{
+	ResourceData: ResourceData{Type: "claims", ID: testClaimID, OrganisationID: testOrganisationID},
+	Attributes: &ClaimAttributes{
+		Amount:           "100.00",
+		Currency:         "GBP",
+		Scheme:           SchemeBacs,
+		ReasonCode:       ClaimReasonAmountDiffers,
+		DirectDebitID:    testDirectDebitID,
+		MandateReference: "REF-0001",
+	},
+}}
+
+func TestClaimsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+claimsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		response, _ := json.Marshal(expectedClaim)
+		testBody(t, r, bytes.NewBuffer(response))
+		writeJSON(t, w, expectedClaim)
+	})
+
+	c, _, err := client.Claims.Create(ctx, expectedClaim)
+	if err != nil {
+		t.Errorf("Claims.Create returned error: %v", err)
+	}
+	if !reflect.DeepEqual(c, expectedClaim) {
+		t.Errorf("Claims.Create returned %+v, expected %+v", c, expectedClaim)
+	}
+}
+
+func TestClaimsService_Fetch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+claimsPath+"/"+testClaimID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		writeJSON(t, w, expectedClaim)
+	})
+
+	c, _, err := client.Claims.Fetch(ctx, testClaimID)
+	if err != nil {
+		t.Errorf("Claims.Fetch returned error: %v", err)
+	}
+	if !reflect.DeepEqual(c, expectedClaim) {
+		t.Errorf("Claims.Fetch returned %+v, expected %+v", c, expectedClaim)
+	}
+}
+
+func TestClaimsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+claimsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testQueryParam(t, r, "page[number]", "0")
+		testQueryParam(t, r, "page[size]", "10")
+		writeJSON(t, w, ClaimList{Data: []*ClaimData{expectedClaim.Data}})
+	})
+
+	list, _, err := client.Claims.List(ctx, 0, 10)
+	if err != nil {
+		t.Errorf("Claims.List returned error: %v", err)
+	}
+	if len(list.Data) != 1 || !reflect.DeepEqual(list.Data[0], expectedClaim.Data) {
+		t.Errorf("Claims.List returned %+v, expected %+v", list, expectedClaim)
+	}
+}
+
+func TestClaimsService_Submission(t *testing.T) {
+	setup()
+	defer teardown()
+
+	submission := &ClaimSubmission{Data: &ClaimSubmissionData{
+		ResourceData: ResourceData{Type: "claim_submissions", ID: testSubmissionID},
+		Attributes:   &ClaimSubmissionAttributes{Status: ClaimStatusPending},
+	}}
+	mux.HandleFunc("/v1/"+claimsPath+"/"+testClaimID+"/submissions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		writeJSON(t, w, submission)
+	})
+	mux.HandleFunc("/v1/"+claimsPath+"/"+testClaimID+"/submissions/"+testSubmissionID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		writeJSON(t, w, submission)
+	})
+
+	created, _, err := client.Claims.CreateSubmission(ctx, testClaimID, submission)
+	if err != nil || !reflect.DeepEqual(created, submission) {
+		t.Errorf("Claims.CreateSubmission returned %+v, %v", created, err)
+	}
+
+	fetched, _, err := client.Claims.FetchSubmission(ctx, testClaimID, testSubmissionID)
+	if err != nil || fetched.Data.Attributes.Status != ClaimStatusPending {
+		t.Errorf("Claims.FetchSubmission returned %+v, %v", fetched, err)
+	}
+}
+
+func TestClaimsService_CreateSubmissionGeneratesID(t *testing.T) {
+	setup()
+	defer teardown()
+	client.GenerateIDs = true
+
+	mux.HandleFunc("/v1/"+claimsPath+"/"+testClaimID+"/submissions", echoGeneratedID(t))
+
+	submission := &ClaimSubmission{Data: &ClaimSubmissionData{}}
+	if created, _, err := client.Claims.CreateSubmission(ctx, testClaimID, submission); err != nil || created.Data.ID == "" || created.Data.ID != submission.Data.ID {
+		t.Errorf("Claims.CreateSubmission returned %+v, %v", created, err)
+	}
+}
+
+func TestClaimsService_errors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+claimsPath+"/"+testClaimID, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		writeJSON(t, w, map[string]string{"error_message": "claim not found"})
+	})
+
+	_, _, err := client.Claims.Fetch(ctx, testClaimID)
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) || errorResponse.ErrorMessage != "claim not found" {
+		t.Errorf("Claims.Fetch returned %v, want ErrorResponse", err)
+	}
+
+	if _, _, err := client.Claims.FetchSubmission(ctx, testClaimID, ".."); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("Claims.FetchSubmission returned %v, want ErrInvalidUUID", err)
+	}
+}
@@ -0,0 +1,118 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// defaultDeleteConcurrency is the number of parallel deletes used by DeleteMatching when none is given.
+const defaultDeleteConcurrency = 4
+
+// DeleteMatchingOptions configures AccountsService.DeleteMatching.
+type DeleteMatchingOptions struct {
+	// Filter narrows down the listed accounts on the server, e.g. {"bank_id": "400300"},
+	// and is sent as filter[key]=value query parameters.
+	Filter map[string]string
+	// Match selects the accounts to delete among the listed ones. A nil Match selects every listed account.
+	Match func(account *AccountData) bool
+	// DryRun reports the matching accounts without deleting them.
+	DryRun bool
+	// Concurrency is the number of accounts deleted in parallel. Defaults to 4.
+	Concurrency int
+	// PageSize is the page size used when listing accounts. Defaults to 100.
+	PageSize int
+}
+
+// DeleteReport summarises the outcome of AccountsService.DeleteMatching.
+type DeleteReport struct {
+	// Matched holds the IDs of all accounts selected by the filter.
+	Matched []string
+	// Deleted holds the IDs of the accounts that were deleted.
+	Deleted []string
+	// Failed maps the IDs of the accounts that could not be deleted to the cause.
+	Failed map[string]error
+}
+
+// DeleteMatching deletes every account listed with opts.Filter and selected by opts.Match.
+// All pages are listed before the first delete, so deleting does not shift the pages being read.
+// The current version of each account is fetched right before it is deleted.
+// Failures of single accounts are collected in the report; an error is only
+// returned if the accounts could not be listed.
+func (s *AccountsService) DeleteMatching(ctx context.Context, opts *DeleteMatchingOptions) (*DeleteReport, error) {
+	if opts == nil {
+		opts = &DeleteMatchingOptions{}
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultExportPageSize
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultDeleteConcurrency
+	}
+
+	report := &DeleteReport{Failed: make(map[string]error)}
+	for page := 0; ; page++ {
+		list, _, err := s.resources.List(ctx, &ListOptions{PageNumber: page, PageSize: pageSize, Filter: opts.Filter})
+		if err != nil {
+			return report, err
+		}
+		for _, account := range list {
+			if account != nil && (opts.Match == nil || opts.Match(account)) {
+				report.Matched = append(report.Matched, account.ID.String())
+			}
+		}
+		if len(list) < pageSize {
+			break
+		}
+	}
+
+	if opts.DryRun {
+		return report, nil
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		ids = make(chan string)
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				err := s.deleteCurrent(ctx, id)
+				mu.Lock()
+				if err != nil {
+					report.Failed[id] = err
+				} else {
+					report.Deleted = append(report.Deleted, id)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, id := range report.Matched {
+		ids <- id
+	}
+	close(ids)
+	wg.Wait()
+	sort.Strings(report.Deleted)
+
+	return report, nil
+}
+
+// deleteCurrent fetches the current version of an account and deletes it.
+func (s *AccountsService) deleteCurrent(ctx context.Context, accountID string) error {
+	account, _, err := s.Fetch(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if account.Data == nil {
+		return fmt.Errorf("fetching account %s: response has no data", accountID)
+	}
+	_, err = s.Delete(ctx, accountID, account.Data.Version)
+	return err
+}
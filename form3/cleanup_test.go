@@ -0,0 +1,166 @@
+package form3
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func setupDeleteMatching(t *testing.T) map[string]string {
+	var mu sync.Mutex
+	deleted := make(map[string]string)
+	accounts := []*AccountData{
+		{ID: "00000000-0000-4000-8000-000000000001", Version: 1, Attributes: &AccountAttributes{Country: "GB"}},
+		{ID: "00000000-0000-4000-8000-000000000002", Version: 0, Attributes: &AccountAttributes{Country: "FR"}},
+		{ID: "00000000-0000-4000-8000-000000000003", Version: 2, Attributes: &AccountAttributes{Country: "GB"}},
+	}
+
+	mux.HandleFunc("/v1/"+accountsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		list := AccountList{Data: []*AccountData{}}
+		if r.URL.Query().Get("page[number]") == "0" {
+			country := r.URL.Query().Get("filter[country]")
+			for _, a := range accounts {
+				if country == "" || a.Attributes.Country == country {
+					list.Data = append(list.Data, a)
+				}
+			}
+		}
+		response, _ := json.Marshal(list)
+		fmt.Fprint(w, string(response))
+	})
+	mux.HandleFunc("/v1/"+accountsPath+"/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/v1/"+accountsPath+"/")
+		switch r.Method {
+		case http.MethodGet:
+			for _, a := range accounts {
+				if a.ID.String() == id {
+					response, _ := json.Marshal(Account{Data: a})
+					fmt.Fprint(w, string(response))
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case http.MethodDelete:
+			if strings.HasSuffix(id, "3") {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			mu.Lock()
+			deleted[id] = r.URL.Query().Get("version")
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	return deleted
+}
+
+func TestAccountsService_DeleteMatching(t *testing.T) {
+	setup()
+	defer teardown()
+	deleted := setupDeleteMatching(t)
+
+	report, err := client.Accounts.DeleteMatching(ctx, &DeleteMatchingOptions{
+		Match: func(a *AccountData) bool { return a.Attributes.Country == "GB" },
+	})
+	if err != nil {
+		t.Fatalf("Accounts.DeleteMatching returned error: %v", err)
+	}
+
+	if want := []string{"00000000-0000-4000-8000-000000000001", "00000000-0000-4000-8000-000000000003"}; !reflect.DeepEqual(report.Matched, want) {
+		t.Errorf("Matched is %v, want %v", report.Matched, want)
+	}
+	if want := []string{"00000000-0000-4000-8000-000000000001"}; !reflect.DeepEqual(report.Deleted, want) {
+		t.Errorf("Deleted is %v, want %v", report.Deleted, want)
+	}
+	if _, ok := report.Failed["00000000-0000-4000-8000-000000000003"]; !ok || len(report.Failed) != 1 {
+		t.Errorf("Failed is %v, want only account 3", report.Failed)
+	}
+	if want := map[string]string{"00000000-0000-4000-8000-000000000001": "1"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("Deleted versions are %v, want %v", deleted, want)
+	}
+}
+
+func TestAccountsService_DeleteMatchingDryRun(t *testing.T) {
+	setup()
+	defer teardown()
+	deleted := setupDeleteMatching(t)
+
+	report, err := client.Accounts.DeleteMatching(ctx, &DeleteMatchingOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Accounts.DeleteMatching returned error: %v", err)
+	}
+
+	if len(report.Matched) != 3 {
+		t.Errorf("Matched is %v, want all accounts", report.Matched)
+	}
+	if len(report.Deleted) != 0 || len(deleted) != 0 {
+		t.Errorf("Dry run should not delete, deleted %v", deleted)
+	}
+}
+
+func TestAccountsService_DeleteMatchingFilter(t *testing.T) {
+	setup()
+	defer teardown()
+	setupDeleteMatching(t)
+
+	report, err := client.Accounts.DeleteMatching(ctx, &DeleteMatchingOptions{
+		Filter: map[string]string{"country": "FR"},
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("Accounts.DeleteMatching returned error: %v", err)
+	}
+	if want := []string{"00000000-0000-4000-8000-000000000002"}; !reflect.DeepEqual(report.Matched, want) {
+		t.Errorf("Matched is %v, want %v", report.Matched, want)
+	}
+}
+
+func TestAccountsService_DeleteMatchingFetchWithoutData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+accountsPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, AccountList{Data: []*AccountData{expectedAccount.Data}})
+	})
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			t.Errorf("Account without data should not be deleted")
+		}
+		fmt.Fprint(w, `{"data":null}`)
+	})
+
+	report, err := client.Accounts.DeleteMatching(ctx, nil)
+	if err != nil {
+		t.Fatalf("Accounts.DeleteMatching returned error: %v", err)
+	}
+	if _, ok := report.Failed[testAccountID]; !ok {
+		t.Errorf("Failed is %v, want the account without data", report.Failed)
+	}
+}
+
+func TestAccountsService_DeleteMatchingEmptyFetch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+accountsPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, AccountList{Data: []*AccountData{expectedAccount.Data}})
+	})
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			t.Errorf("Account without data should not be deleted")
+		}
+	})
+
+	report, err := client.Accounts.DeleteMatching(ctx, nil)
+	if err != nil {
+		t.Fatalf("Accounts.DeleteMatching returned error: %v", err)
+	}
+	if _, ok := report.Failed[testAccountID]; !ok {
+		t.Errorf("Failed is %v, want the account with an empty response", report.Failed)
+	}
+}
@@ -0,0 +1,199 @@
+package form3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DecodeMode selects how strictly Client.Do checks response documents against the types they are decoded into.
+type DecodeMode int
+
+const (
+	// DecodeDefault decodes responses like encoding/json, ignoring unknown fields.
+	DecodeDefault DecodeMode = iota
+	// DecodeStrict fails with a *DecodeError when a response has a field the target type does not
+	// declare, or a resource lacks one of id, type or version while its type declares it.
+	DecodeStrict
+	// DecodeLenient runs the checks of DecodeStrict but only logs the problems found.
+	DecodeLenient
+)
+
+var (
+	// ErrUnknownField is wrapped by a DecodeError for a field the target type does not declare.
+	ErrUnknownField = errors.New("unknown field")
+	// ErrMissingField is wrapped by a DecodeError for a required field absent from the response.
+	ErrMissingField = errors.New("missing required field")
+)
+
+// DecodeError is returned by Client.Do in DecodeStrict mode when a response does not match its target type.
+type DecodeError struct {
+	// Path locates the offending field in the response document, e.g. "data.attributes.bank_id".
+	Path string
+	Err  error
+}
+
+// Error returns a string representation of the decode error.
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decoding response: %s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+	// documentMembers are top level JSON:API members accepted whether or not the target type declares them.
+	documentMembers = []string{"links", "meta", "jsonapi"}
+	// resourceMembers are required in a resource if its type declares them.
+	resourceMembers = []string{"id", "type", "version"}
+)
+
+// checkDocument compares the JSON document b with the type t it was decoded into,
+// and returns the problems found, ordered by path.
+func checkDocument(b []byte, t reflect.Type) []*DecodeError {
+	var problems []*DecodeError
+	checkValue(b, t, "", &problems)
+	return problems
+}
+
+// checkValue appends the problems of the JSON value b, at path, decoded into type t to problems.
+func checkValue(b []byte, t reflect.Type, path string, problems *[]*DecodeError) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == rawMessageType {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(b, &object) != nil || object == nil {
+			return
+		}
+		fields := jsonFields(t)
+		if path == "data" || strings.HasPrefix(path, "data[") && !strings.Contains(path, ".") {
+			for _, name := range resourceMembers {
+				if _, declared := fields[name]; !declared {
+					continue
+				}
+				if _, ok := object[name]; !ok {
+					*problems = append(*problems, &DecodeError{Path: joinFieldPath(path, name), Err: ErrMissingField})
+				}
+			}
+		}
+		for _, name := range sortedKeys(object) {
+			ft, ok := fields[strings.ToLower(name)]
+			if !ok {
+				if path == "" && contains(documentMembers, name) {
+					continue
+				}
+				*problems = append(*problems, &DecodeError{Path: joinFieldPath(path, name), Err: ErrUnknownField})
+				continue
+			}
+			checkValue(object[name], ft, joinFieldPath(path, name), problems)
+		}
+	case reflect.Map:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(b, &object) != nil {
+			return
+		}
+		for _, name := range sortedKeys(object) {
+			checkValue(object[name], t.Elem(), joinFieldPath(path, name), problems)
+		}
+	case reflect.Slice, reflect.Array:
+		var array []json.RawMessage
+		if json.Unmarshal(b, &array) != nil {
+			return
+		}
+		for i, element := range array {
+			checkValue(element, t.Elem(), fmt.Sprintf("%s[%d]", path, i), problems)
+		}
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		if kind := jsonKind(b); kind != "null" && kind != goKindToJSON(t.Kind()) {
+			*problems = append(*problems, &DecodeError{Path: path, Err: fmt.Errorf("cannot decode %s into %v", kind, t)})
+		}
+	}
+}
+
+// jsonKind returns the kind of the JSON value b.
+func jsonKind(b []byte) string {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return "null"
+	}
+	switch b[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
+
+// goKindToJSON returns the kind of JSON value a Go value of kind k is decoded from.
+func goKindToJSON(k reflect.Kind) string {
+	switch k {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	default:
+		return "number"
+	}
+}
+
+// decodeError converts err, returned by encoding/json for the document b decoded into type t,
+// into a DecodeError naming the offending field where possible.
+func decodeError(b []byte, t reflect.Type, err error) error {
+	for _, problem := range checkDocument(b, t) {
+		if !errors.Is(problem, ErrUnknownField) && !errors.Is(problem, ErrMissingField) {
+			return problem
+		}
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return &DecodeError{Path: typeErr.Field, Err: err}
+	}
+	return &DecodeError{Path: "(document)", Err: err}
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func sortedKeys(object map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
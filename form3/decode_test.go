@@ -0,0 +1,84 @@
+package form3
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDecodeStrict(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		path     string
+		want     error
+	}{
+		{
+			name:     "valid",
+			document: `{"data":{"type":"accounts","id":"` + testAccountID + `","version":0,"attributes":{"country":"GB"}},"links":{"self":"x"}}`,
+		},
+		{
+			name:     "unknown attribute",
+			document: `{"data":{"type":"accounts","id":"` + testAccountID + `","version":0,"attributes":{"country":"GB","iban_v2":"x"}}}`,
+			path:     "data.attributes.iban_v2",
+			want:     ErrUnknownField,
+		},
+		{
+			name:     "missing version",
+			document: `{"data":{"type":"accounts","id":"` + testAccountID + `"}}`,
+			path:     "data.version",
+			want:     ErrMissingField,
+		},
+		{
+			name:     "wrong type",
+			document: `{"data":{"type":"accounts","id":"` + testAccountID + `","version":"1"}}`,
+			path:     "data.version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+			client.DecodeMode = DecodeStrict
+
+			mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.document)
+			})
+
+			_, _, err := client.Accounts.Fetch(ctx, testAccountID)
+			if tt.path == "" {
+				if err != nil {
+					t.Errorf("Accounts.Fetch returned error: %v", err)
+				}
+				return
+			}
+			var decodeErr *DecodeError
+			if !errors.As(err, &decodeErr) || decodeErr.Path != tt.path {
+				t.Fatalf("Accounts.Fetch returned %v, want DecodeError at %s", err, tt.path)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Accounts.Fetch returned %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeLenient(t *testing.T) {
+	setup()
+	defer teardown()
+	client.DecodeMode = DecodeLenient
+
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"type":"accounts","id":"`+testAccountID+`","attributes":{"country":"GB","iban_v2":"x"}}}`)
+	})
+
+	account, _, err := client.Accounts.Fetch(ctx, testAccountID)
+	if err != nil {
+		t.Fatalf("Accounts.Fetch returned error: %v", err)
+	}
+	if account.Data.Attributes.Country != "GB" {
+		t.Errorf("Accounts.Fetch returned %+v", account.Data.Attributes)
+	}
+}
@@ -0,0 +1,256 @@
+package form3
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// directDebitsPath URL path to direct debit resources.
+	directDebitsPath = "transaction/directdebits"
+	// decisionsPath URL path segment of decision sub-resources.
+	decisionsPath = "decisions"
+	// reversalsPath URL path segment of reversal sub-resources.
+	reversalsPath = "reversals"
+)
+
+// Decision is the answer given in a direct debit decision.
+type Decision string
+
+// Answers that can be given in a direct debit decision.
+const (
+	DecisionAccept Decision = "accept"
+	DecisionReject Decision = "reject"
+)
+
+// DirectDebit represents a direct debit collected through Form3.
+type DirectDebit struct {
+	Data *DirectDebitData `json:"data"`
+}
+
+// DirectDebitList represents a list of direct debits.
+type DirectDebitList struct {
+	Data []*DirectDebitData `json:"data"`
+}
+
+// DirectDebitData represents the main attributes for a given direct debit.
+type DirectDebitData struct {
+	ResourceData
+	Attributes *DirectDebitAttributes `json:"attributes"`
+}
+
+// DirectDebitAttributes represents the available direct debit attribute fields.
+type DirectDebitAttributes struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+	Scheme   Scheme `json:"scheme,omitempty"`
+	// MandateReference is the reference of the mandate the direct debit is collected under.
+	MandateReference string `json:"mandate_reference,omitempty"`
+	Reference        string `json:"reference,omitempty"`
+	NumericReference string `json:"numeric_reference,omitempty"`
+	ProcessingDate   string `json:"processing_date,omitempty"`
+	DebtorParty      *Party `json:"debtor_party,omitempty"`
+	BeneficiaryParty *Party `json:"beneficiary_party,omitempty"`
+	Status           string `json:"status,omitempty"`
+}
+
+// DirectDebitDecision represents the decision to accept or reject a direct debit.
+type DirectDebitDecision struct {
+	Data *DirectDebitDecisionData `json:"data"`
+}
+
+// DirectDebitDecisionData represents the main attributes for a given direct debit decision.
+type DirectDebitDecisionData struct {
+	ResourceData
+	Attributes *DecisionAttributes `json:"attributes"`
+}
+
+// DecisionAttributes represents the answer given in a decision.
+type DecisionAttributes struct {
+	// Answer is either DecisionAccept or DecisionReject.
+	Answer Decision `json:"answer"`
+	Reason string   `json:"reason,omitempty"`
+	Status string   `json:"status,omitempty"`
+}
+
+// DirectDebitReversal represents the reversal of a collected direct debit.
+type DirectDebitReversal struct {
+	Data *DirectDebitReversalData `json:"data"`
+}
+
+// DirectDebitReversalData represents the main attributes for a given direct debit reversal.
+type DirectDebitReversalData struct {
+	ResourceData
+	Attributes *ReversalAttributes `json:"attributes,omitempty"`
+}
+
+// ReversalAttributes represents the status of a reversal.
+type ReversalAttributes struct {
+	Status string `json:"status,omitempty"`
+}
+
+// DirectDebitsService handles the communication with the direct debit related
+// methods of the Form3 API.
+//
+// Form3 API docs: https://api-docs.form3.tech/api.html?http#direct-debits
+type DirectDebitsService service
+
+// Create creates a new direct debit.
+// If Client.GenerateIDs is set and the direct debit has no ID, a random one is assigned to directDebit before sending.
+func (s *DirectDebitsService) Create(ctx context.Context, directDebit *DirectDebit) (*DirectDebit, *http.Response, error) {
+	if directDebit != nil {
+		generateID(s.client, directDebit.Data)
+	}
+	request, err := s.client.NewRequest(http.MethodPost, directDebitsPath, directDebit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dd := new(DirectDebit)
+	resp, err := s.client.Do(ctx, request, dd)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return dd, resp, nil
+}
+
+// Fetch gets a single direct debit using the direct debit ID.
+func (s *DirectDebitsService) Fetch(ctx context.Context, directDebitID string) (*DirectDebit, *http.Response, error) {
+	if err := validateIDs(directDebitID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(directDebitsPath, directDebitID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dd := new(DirectDebit)
+	resp, err := s.client.Do(ctx, request, dd)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return dd, resp, nil
+}
+
+// List lists all direct debits. Supports pagination.
+func (s *DirectDebitsService) List(ctx context.Context, pageNumber int, pageSize int) (*DirectDebitList, *http.Response, error) {
+	path := s.client.listPath(directDebitsPath, pageNumber, pageSize)
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(DirectDebitList)
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, nil
+}
+
+// CreateDecision accepts or rejects a direct debit.
+// If Client.GenerateIDs is set and the decision has no ID, a random one is assigned to decision before sending.
+func (s *DirectDebitsService) CreateDecision(ctx context.Context, directDebitID string, decision *DirectDebitDecision) (*DirectDebitDecision, *http.Response, error) {
+	if err := validateIDs(directDebitID); err != nil {
+		return nil, nil, err
+	}
+	if decision != nil {
+		generateID(s.client, decision.Data)
+	}
+	path, err := joinPath(directDebitsPath, directDebitID, decisionsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodPost, path, decision)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d := new(DirectDebitDecision)
+	resp, err := s.client.Do(ctx, request, d)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return d, resp, nil
+}
+
+// FetchDecision gets a single decision of a direct debit.
+func (s *DirectDebitsService) FetchDecision(ctx context.Context, directDebitID string, decisionID string) (*DirectDebitDecision, *http.Response, error) {
+	if err := validateIDs(directDebitID, decisionID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(directDebitsPath, directDebitID, decisionsPath, decisionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d := new(DirectDebitDecision)
+	resp, err := s.client.Do(ctx, request, d)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return d, resp, nil
+}
+
+// CreateReversal reverses a collected direct debit.
+// If Client.GenerateIDs is set and the reversal has no ID, a random one is assigned to reversal before sending.
+func (s *DirectDebitsService) CreateReversal(ctx context.Context, directDebitID string, reversal *DirectDebitReversal) (*DirectDebitReversal, *http.Response, error) {
+	if err := validateIDs(directDebitID); err != nil {
+		return nil, nil, err
+	}
+	if reversal != nil {
+		generateID(s.client, reversal.Data)
+	}
+	path, err := joinPath(directDebitsPath, directDebitID, reversalsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodPost, path, reversal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := new(DirectDebitReversal)
+	resp, err := s.client.Do(ctx, request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// FetchReversal gets a single reversal of a direct debit.
+func (s *DirectDebitsService) FetchReversal(ctx context.Context, directDebitID string, reversalID string) (*DirectDebitReversal, *http.Response, error) {
+	if err := validateIDs(directDebitID, reversalID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(directDebitsPath, directDebitID, reversalsPath, reversalID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := new(DirectDebitReversal)
+	resp, err := s.client.Do(ctx, request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
@@ -0,0 +1,204 @@
+package form3
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const (
+	testDirectDebitID = "3f6a1c2e-9f7b-4b8e-a1d4-6c5e2b7a9d10"
+	testDecisionID    = "5b8e2d4c-1a3f-4e6b-9c7d-2f1a8b3c6e40"
+)
+
+var expectedDirectDebit = &DirectDebit{Data: &DirectDebitData{
+	ResourceData: ResourceData{
+		Type:           "directdebits",
+		ID:             testDirectDebitID,
+		OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+	},
+	Attributes: &DirectDebitAttributes{
+		Amount:           "100.00",
+		Currency:         "GBP",
+		Scheme:           SchemeBacs,
+		MandateReference: "REF-0001",
+		DebtorParty:      &Party{AccountNumber: "41426819", AccountWith: &AccountWith{BankID: "400300"}},
+	},
+}}
+
+func TestDirectDebitsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+directDebitsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		writeJSON(t, w, expectedDirectDebit)
+	})
+
+	dd, _, err := client.DirectDebits.Create(ctx, expectedDirectDebit)
+	if err != nil {
+		t.Errorf("DirectDebits.Create returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(dd, expectedDirectDebit) {
+		t.Errorf("DirectDebits.Create returned %+v, expected %+v", dd, expectedDirectDebit)
+	}
+}
+
+func TestDirectDebitsService_Fetch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+directDebitsPath+"/"+testDirectDebitID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		writeJSON(t, w, expectedDirectDebit)
+	})
+
+	dd, _, err := client.DirectDebits.Fetch(ctx, testDirectDebitID)
+	if err != nil {
+		t.Errorf("DirectDebits.Fetch returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(dd, expectedDirectDebit) {
+		t.Errorf("DirectDebits.Fetch returned %+v, expected %+v", dd, expectedDirectDebit)
+	}
+}
+
+func TestDirectDebitsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+directDebitsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testQueryParam(t, r, "page[number]", "0")
+		testQueryParam(t, r, "page[size]", "5")
+		writeJSON(t, w, DirectDebitList{Data: []*DirectDebitData{expectedDirectDebit.Data}})
+	})
+
+	list, _, err := client.DirectDebits.List(ctx, 0, 5)
+	if err != nil {
+		t.Errorf("DirectDebits.List returned error: %v", err)
+	}
+
+	if len(list.Data) != 1 || !reflect.DeepEqual(list.Data[0], expectedDirectDebit.Data) {
+		t.Errorf("DirectDebits.List returned %+v, expected %+v", list, expectedDirectDebit)
+	}
+}
+
+func TestDirectDebitsService_Decision(t *testing.T) {
+	setup()
+	defer teardown()
+
+	decision := &DirectDebitDecision{Data: &DirectDebitDecisionData{
+		ResourceData: ResourceData{Type: "directdebit_decisions", ID: testDecisionID},
+		Attributes:   &DecisionAttributes{Answer: DecisionReject, Reason: "insufficient funds"},
+	}}
+	mux.HandleFunc("/v1/"+directDebitsPath+"/"+testDirectDebitID+"/decisions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		writeJSON(t, w, decision)
+	})
+	mux.HandleFunc("/v1/"+directDebitsPath+"/"+testDirectDebitID+"/decisions/"+testDecisionID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		writeJSON(t, w, decision)
+	})
+
+	created, _, err := client.DirectDebits.CreateDecision(ctx, testDirectDebitID, decision)
+	if err != nil {
+		t.Errorf("DirectDebits.CreateDecision returned error: %v", err)
+	}
+	if !reflect.DeepEqual(created, decision) {
+		t.Errorf("DirectDebits.CreateDecision returned %+v, expected %+v", created, decision)
+	}
+
+	fetched, _, err := client.DirectDebits.FetchDecision(ctx, testDirectDebitID, testDecisionID)
+	if err != nil {
+		t.Errorf("DirectDebits.FetchDecision returned error: %v", err)
+	}
+	if !reflect.DeepEqual(fetched, decision) {
+		t.Errorf("DirectDebits.FetchDecision returned %+v, expected %+v", fetched, decision)
+	}
+}
+
+func TestDirectDebitsService_Reversal(t *testing.T) {
+	setup()
+	defer teardown()
+
+	reversal := &DirectDebitReversal{Data: &DirectDebitReversalData{
+		ResourceData: ResourceData{Type: "directdebit_reversals", ID: testDecisionID},
+	}}
+	mux.HandleFunc("/v1/"+directDebitsPath+"/"+testDirectDebitID+"/reversals", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		writeJSON(t, w, reversal)
+	})
+	mux.HandleFunc("/v1/"+directDebitsPath+"/"+testDirectDebitID+"/reversals/"+testDecisionID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		writeJSON(t, w, reversal)
+	})
+
+	created, _, err := client.DirectDebits.CreateReversal(ctx, testDirectDebitID, reversal)
+	if err != nil {
+		t.Errorf("DirectDebits.CreateReversal returned error: %v", err)
+	}
+	if !reflect.DeepEqual(created, reversal) {
+		t.Errorf("DirectDebits.CreateReversal returned %+v, expected %+v", created, reversal)
+	}
+
+	fetched, _, err := client.DirectDebits.FetchReversal(ctx, testDirectDebitID, testDecisionID)
+	if err != nil {
+		t.Errorf("DirectDebits.FetchReversal returned error: %v", err)
+	}
+	if !reflect.DeepEqual(fetched, reversal) {
+		t.Errorf("DirectDebits.FetchReversal returned %+v, expected %+v", fetched, reversal)
+	}
+}
+
+func TestDirectDebitsService_CreateGeneratesIDs(t *testing.T) {
+	setup()
+	defer teardown()
+	client.GenerateIDs = true
+
+	mux.HandleFunc("/v1/"+directDebitsPath, echoGeneratedID(t))
+	mux.HandleFunc("/v1/"+directDebitsPath+"/"+testDirectDebitID+"/decisions", echoGeneratedID(t))
+	mux.HandleFunc("/v1/"+directDebitsPath+"/"+testDirectDebitID+"/reversals", echoGeneratedID(t))
+
+	directDebit := &DirectDebit{Data: &DirectDebitData{Attributes: &DirectDebitAttributes{Amount: "100.00", Currency: "GBP"}}}
+	if created, _, err := client.DirectDebits.Create(ctx, directDebit); err != nil || created.Data.ID == "" || created.Data.ID != directDebit.Data.ID {
+		t.Errorf("DirectDebits.Create returned %+v, %v", created, err)
+	}
+	decision := &DirectDebitDecision{Data: &DirectDebitDecisionData{Attributes: &DecisionAttributes{Answer: DecisionAccept}}}
+	if created, _, err := client.DirectDebits.CreateDecision(ctx, testDirectDebitID, decision); err != nil || created.Data.ID == "" || created.Data.ID != decision.Data.ID {
+		t.Errorf("DirectDebits.CreateDecision returned %+v, %v", created, err)
+	}
+	reversal := &DirectDebitReversal{Data: &DirectDebitReversalData{}}
+	if created, _, err := client.DirectDebits.CreateReversal(ctx, testDirectDebitID, reversal); err != nil || created.Data.ID == "" || created.Data.ID != reversal.Data.ID {
+		t.Errorf("DirectDebits.CreateReversal returned %+v, %v", created, err)
+	}
+}
+
+func TestDirectDebitsService_invalidIDs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+	})
+
+	for _, id := range []string{"", "..", "../accounts"} {
+		if _, _, err := client.DirectDebits.Fetch(ctx, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("DirectDebits.Fetch(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, _, err := client.DirectDebits.CreateDecision(ctx, id, &DirectDebitDecision{}); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("DirectDebits.CreateDecision(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, _, err := client.DirectDebits.FetchDecision(ctx, testDirectDebitID, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("DirectDebits.FetchDecision(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, _, err := client.DirectDebits.CreateReversal(ctx, id, &DirectDebitReversal{}); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("DirectDebits.CreateReversal(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, _, err := client.DirectDebits.FetchReversal(ctx, testDirectDebitID, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("DirectDebits.FetchReversal(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+	}
+}
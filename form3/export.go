@@ -0,0 +1,486 @@
+package form3
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultExportPageSize is the page size used by Export when none is given.
+	defaultExportPageSize = 100
+	// defaultMultiValueSeparator joins multi-value fields such as Name in a single CSV cell.
+	defaultMultiValueSeparator = ";"
+)
+
+// AccountEncoder writes accounts to an underlying stream.
+type AccountEncoder interface {
+	Encode(account *AccountData) error
+}
+
+// AccountDecoder reads accounts from an underlying stream.
+// Decode returns io.EOF when there are no more accounts.
+type AccountDecoder interface {
+	Decode() (*AccountData, error)
+}
+
+// CSVColumn maps a single CSV column to an account field.
+type CSVColumn struct {
+	// Header is the column name in the CSV header row.
+	Header string
+	// Field is the JSON name of the account field, e.g. "id" or "bank_id".
+	// Attribute fields are addressed by their name without any prefix.
+	Field string
+}
+
+// CSVOptions configures the CSV encoder and decoder.
+type CSVOptions struct {
+	// Columns holds the column mapping. If empty, DefaultCSVColumns is used.
+	Columns []CSVColumn
+	// Comma is the field delimiter. Defaults to ','.
+	Comma rune
+	// MultiValueSeparator joins the values of Name and AlternativeNames
+	// in a single cell. Defaults to ";".
+	MultiValueSeparator string
+}
+
+// csvField knows how to read and write a single account field as text.
+type csvField struct {
+	get func(a *AccountData, sep string) string
+	set func(a *AccountData, value string, sep string) error
+}
+
+// csvFields holds all account fields that can be mapped to CSV columns.
+var csvFields = map[string]csvField{
+	"type":            stringField(func(a *AccountData) *string { return &a.Type }),
+	"id":              uuidField(func(a *AccountData) *UUID { return &a.ID }),
+	"organisation_id": uuidField(func(a *AccountData) *UUID { return &a.OrganisationID }),
+	"version": {
+		get: func(a *AccountData, _ string) string { return strconv.Itoa(a.Version) },
+		set: func(a *AccountData, value string, _ string) error {
+			if value == "" {
+				a.Version = 0
+				return nil
+			}
+			v, err := strconv.Atoi(value)
+			if err != nil {
+				return err
+			}
+			a.Version = v
+			return nil
+		},
+	},
+	"created_on":  timestampField(func(a *AccountData) **Timestamp { return &a.CreatedOn }),
+	"modified_on": timestampField(func(a *AccountData) **Timestamp { return &a.ModifiedOn }),
+
+	"country":        attributeStringField(func(a *AccountAttributes) *string { return &a.Country }),
+	"base_currency":  attributeStringField(func(a *AccountAttributes) *string { return &a.BaseCurrency }),
+	"account_number": attributeStringField(func(a *AccountAttributes) *string { return &a.AccountNumber }),
+	"bank_id":        attributeStringField(func(a *AccountAttributes) *string { return &a.BankID }),
+	"bank_id_code":   attributeStringField(func(a *AccountAttributes) *string { return &a.BankIDCode }),
+	"bic":            attributeStringField(func(a *AccountAttributes) *string { return &a.Bic }),
+	"iban":           attributeStringField(func(a *AccountAttributes) *string { return &a.Iban }),
+	"customer_id":    attributeStringField(func(a *AccountAttributes) *string { return &a.CustomerID }),
+	"account_classification": attributeStringField(func(a *AccountAttributes) *string {
+		return &a.AccountClassification
+	}),
+	"secondary_identification": attributeStringField(func(a *AccountAttributes) *string {
+		return &a.SecondaryIdentification
+	}),
+	"status": attributeStringField(func(a *AccountAttributes) *string { return &a.Status }),
+
+	"name":              attributeListField(func(a *AccountAttributes) *[]string { return &a.Name }),
+	"alternative_names": attributeListField(func(a *AccountAttributes) *[]string { return &a.AlternativeNames }),
+
+	"joint_account": attributeBoolField(func(a *AccountAttributes) *bool { return &a.JointAccount }),
+	"account_matching_opt_out": attributeBoolField(func(a *AccountAttributes) *bool {
+		return &a.AccountMatchingOptOut
+	}),
+	"switched": attributeBoolField(func(a *AccountAttributes) *bool { return &a.Switched }),
+}
+
+// DefaultCSVColumns is the column mapping used when CSVOptions.Columns is empty.
+// It maps every account field, so a default export can be imported again without losing data.
+// Relationships and unknown members are not exported to CSV.
+var DefaultCSVColumns = []CSVColumn{
+	{Header: "type", Field: "type"},
+	{Header: "id", Field: "id"},
+	{Header: "organisation_id", Field: "organisation_id"},
+	{Header: "version", Field: "version"},
+	{Header: "country", Field: "country"},
+	{Header: "base_currency", Field: "base_currency"},
+	{Header: "account_number", Field: "account_number"},
+	{Header: "bank_id", Field: "bank_id"},
+	{Header: "bank_id_code", Field: "bank_id_code"},
+	{Header: "bic", Field: "bic"},
+	{Header: "iban", Field: "iban"},
+	{Header: "customer_id", Field: "customer_id"},
+	{Header: "name", Field: "name"},
+	{Header: "alternative_names", Field: "alternative_names"},
+	{Header: "account_classification", Field: "account_classification"},
+	{Header: "joint_account", Field: "joint_account"},
+	{Header: "account_matching_opt_out", Field: "account_matching_opt_out"},
+	{Header: "secondary_identification", Field: "secondary_identification"},
+	{Header: "switched", Field: "switched"},
+	{Header: "status", Field: "status"},
+	{Header: "created_on", Field: "created_on"},
+	{Header: "modified_on", Field: "modified_on"},
+}
+
+func stringField(ptr func(a *AccountData) *string) csvField {
+	return csvField{
+		get: func(a *AccountData, _ string) string { return *ptr(a) },
+		set: func(a *AccountData, value string, _ string) error {
+			*ptr(a) = value
+			return nil
+		},
+	}
+}
+
+func uuidField(ptr func(a *AccountData) *UUID) csvField {
+	return csvField{
+		get: func(a *AccountData, _ string) string { return ptr(a).String() },
+		set: func(a *AccountData, value string, _ string) error {
+			if value == "" {
+				*ptr(a) = ""
+				return nil
+			}
+			id, err := ParseUUID(value)
+			if err != nil {
+				return err
+			}
+			*ptr(a) = id
+			return nil
+		},
+	}
+}
+
+func timestampField(ptr func(a *AccountData) **Timestamp) csvField {
+	return csvField{
+		get: func(a *AccountData, _ string) string {
+			if *ptr(a) == nil {
+				return ""
+			}
+			return (*ptr(a)).String()
+		},
+		set: func(a *AccountData, value string, _ string) error {
+			if value == "" {
+				*ptr(a) = nil
+				return nil
+			}
+			t, err := ParseTimestamp(value)
+			if err != nil {
+				return err
+			}
+			*ptr(a) = &t
+			return nil
+		},
+	}
+}
+
+func attributeStringField(ptr func(a *AccountAttributes) *string) csvField {
+	return csvField{
+		get: func(a *AccountData, _ string) string {
+			if a.Attributes == nil {
+				return ""
+			}
+			return *ptr(a.Attributes)
+		},
+		set: func(a *AccountData, value string, _ string) error {
+			*ptr(attributesOf(a)) = value
+			return nil
+		},
+	}
+}
+
+func attributeListField(ptr func(a *AccountAttributes) *[]string) csvField {
+	return csvField{
+		get: func(a *AccountData, sep string) string {
+			if a.Attributes == nil {
+				return ""
+			}
+			return strings.Join(*ptr(a.Attributes), sep)
+		},
+		set: func(a *AccountData, value string, sep string) error {
+			if value == "" {
+				*ptr(attributesOf(a)) = nil
+				return nil
+			}
+			values := strings.Split(value, sep)
+			for i := range values {
+				values[i] = strings.TrimSpace(values[i])
+			}
+			*ptr(attributesOf(a)) = values
+			return nil
+		},
+	}
+}
+
+func attributeBoolField(ptr func(a *AccountAttributes) *bool) csvField {
+	return csvField{
+		get: func(a *AccountData, _ string) string {
+			if a.Attributes == nil {
+				return "false"
+			}
+			return strconv.FormatBool(*ptr(a.Attributes))
+		},
+		set: func(a *AccountData, value string, _ string) error {
+			if value == "" {
+				*ptr(attributesOf(a)) = false
+				return nil
+			}
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			*ptr(attributesOf(a)) = b
+			return nil
+		},
+	}
+}
+
+// attributesOf returns the attributes of a, allocating them if needed.
+func attributesOf(a *AccountData) *AccountAttributes {
+	if a.Attributes == nil {
+		a.Attributes = new(AccountAttributes)
+	}
+	return a.Attributes
+}
+
+// resolve validates the options and returns the columns with their fields.
+func (o *CSVOptions) resolve() ([]CSVColumn, []csvField, rune, string, error) {
+	columns := DefaultCSVColumns
+	comma := ','
+	sep := defaultMultiValueSeparator
+	if o != nil {
+		if len(o.Columns) > 0 {
+			columns = o.Columns
+		}
+		if o.Comma != 0 {
+			comma = o.Comma
+		}
+		if o.MultiValueSeparator != "" {
+			sep = o.MultiValueSeparator
+		}
+	}
+
+	fields := make([]csvField, len(columns))
+	for i, c := range columns {
+		f, ok := csvFields[c.Field]
+		if !ok {
+			return nil, nil, 0, "", fmt.Errorf("unknown account field %q for CSV column %q", c.Field, c.Header)
+		}
+		fields[i] = f
+	}
+	return columns, fields, comma, sep, nil
+}
+
+// CSVEncoder writes accounts as CSV records. The header row is written
+// before the first account.
+type CSVEncoder struct {
+	w             *csv.Writer
+	columns       []CSVColumn
+	fields        []csvField
+	sep           string
+	err           error
+	headerWritten bool
+}
+
+// NewCSVEncoder returns a new CSVEncoder that writes to w.
+// If opts is nil, the default column mapping is used.
+func NewCSVEncoder(w io.Writer, opts *CSVOptions) *CSVEncoder {
+	columns, fields, comma, sep, err := opts.resolve()
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	return &CSVEncoder{w: cw, columns: columns, fields: fields, sep: sep, err: err}
+}
+
+// Encode writes a single account as a CSV record.
+func (e *CSVEncoder) Encode(account *AccountData) error {
+	if e.err != nil {
+		return e.err
+	}
+	if !e.headerWritten {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, len(e.fields))
+	for i, f := range e.fields {
+		record[i] = f.get(account, e.sep)
+	}
+	return e.w.Write(record)
+}
+
+// Flush writes any buffered data, including the header row
+// if no account has been encoded yet.
+func (e *CSVEncoder) Flush() error {
+	if e.err != nil {
+		return e.err
+	}
+	if !e.headerWritten {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *CSVEncoder) writeHeader() error {
+	header := make([]string, len(e.columns))
+	for i, c := range e.columns {
+		header[i] = c.Header
+	}
+	e.headerWritten = true
+	return e.w.Write(header)
+}
+
+// CSVDecoder reads accounts from CSV records. The first record must be
+// the header row; columns are matched by their header, so their order may
+// differ from the configured mapping. Unmapped columns are ignored.
+type CSVDecoder struct {
+	r       *csv.Reader
+	columns []CSVColumn
+	fields  []csvField
+	sep     string
+	err     error
+	// index maps a record position to the field it holds, -1 if unmapped.
+	index []int
+	// records counts the data records read so far, used in error messages.
+	records int
+}
+
+// NewCSVDecoder returns a new CSVDecoder that reads from r.
+// If opts is nil, the default column mapping is used.
+func NewCSVDecoder(r io.Reader, opts *CSVOptions) *CSVDecoder {
+	columns, fields, comma, sep, err := opts.resolve()
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	return &CSVDecoder{r: cr, columns: columns, fields: fields, sep: sep, err: err}
+}
+
+// Decode reads the next account. It returns io.EOF when the input is exhausted.
+func (d *CSVDecoder) Decode() (*AccountData, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if d.index == nil {
+		if err := d.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	record, err := d.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	d.records++
+
+	account := new(AccountData)
+	for i, value := range record {
+		if i >= len(d.index) || d.index[i] < 0 {
+			continue
+		}
+		f := d.index[i]
+		if err := d.fields[f].set(account, value, d.sep); err != nil {
+			return nil, fmt.Errorf("record %d: column %q: %w", d.records, d.columns[f].Header, err)
+		}
+	}
+	return account, nil
+}
+
+func (d *CSVDecoder) readHeader() error {
+	header, err := d.r.Read()
+	if err != nil {
+		return err
+	}
+
+	d.index = make([]int, len(header))
+	for i, h := range header {
+		d.index[i] = -1
+		for f, c := range d.columns {
+			if strings.TrimSpace(h) == c.Header {
+				d.index[i] = f
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// NDJSONEncoder writes accounts as newline-delimited JSON, one account per line.
+type NDJSONEncoder struct {
+	enc *json.Encoder
+}
+
+// NewNDJSONEncoder returns a new NDJSONEncoder that writes to w.
+func NewNDJSONEncoder(w io.Writer) *NDJSONEncoder {
+	return &NDJSONEncoder{enc: json.NewEncoder(w)}
+}
+
+// Encode writes a single account followed by a newline.
+func (e *NDJSONEncoder) Encode(account *AccountData) error {
+	return e.enc.Encode(account)
+}
+
+// NDJSONDecoder reads accounts from newline-delimited JSON.
+type NDJSONDecoder struct {
+	dec *json.Decoder
+}
+
+// NewNDJSONDecoder returns a new NDJSONDecoder that reads from r.
+func NewNDJSONDecoder(r io.Reader) *NDJSONDecoder {
+	return &NDJSONDecoder{dec: json.NewDecoder(r)}
+}
+
+// Decode reads the next account. It returns io.EOF when the input is exhausted.
+func (d *NDJSONDecoder) Decode() (*AccountData, error) {
+	account := new(AccountData)
+	if err := d.dec.Decode(account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Export pages through all accounts and writes each of them to enc.
+// If pageSize is not positive, a default of 100 is used.
+// The number of exported accounts is returned, also when an error occurs.
+// Encoders with a Flush method, such as CSVEncoder, are flushed at the end.
+func (s *AccountsService) Export(ctx context.Context, enc AccountEncoder, pageSize int) (int, error) {
+	if pageSize <= 0 {
+		pageSize = defaultExportPageSize
+	}
+
+	count := 0
+	for page := 0; ; page++ {
+		list, _, err := s.List(ctx, page, pageSize)
+		if err != nil {
+			return count, err
+		}
+
+		for _, account := range list.Data {
+			if err := enc.Encode(account); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if len(list.Data) < pageSize {
+			break
+		}
+	}
+
+	if f, ok := enc.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
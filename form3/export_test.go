@@ -0,0 +1,201 @@
+package form3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCSVEncoder_roundTrip(t *testing.T) {
+	createdOn, err := ParseTimestamp("2021-03-05T05:06:07.089Z")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	want := *expectedAccount.Data
+	want.Version = 2
+	want.CreatedOn, want.ModifiedOn = &createdOn, &createdOn
+	attributes := *want.Attributes
+	attributes.AccountNumber = "41426819"
+	attributes.Iban = "GB11NWBK40030041426819"
+	attributes.CustomerID = "customer-1"
+	attributes.JointAccount = true
+	attributes.AccountMatchingOptOut = true
+	attributes.Switched = true
+	attributes.Status = "confirmed"
+	want.Attributes = &attributes
+
+	var buf bytes.Buffer
+	enc := NewCSVEncoder(&buf, nil)
+	if err := enc.Encode(&want); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	dec := NewCSVDecoder(&buf, nil)
+	got, err := dec.Decode()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if !reflect.DeepEqual(got, &want) {
+		t.Errorf("Decode returned %+v, %+v, expected %+v, %+v", got, got.Attributes, &want, want.Attributes)
+	}
+
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("Decode returned %v, expected io.EOF", err)
+	}
+}
+
+func TestCSVEncoder_customColumns(t *testing.T) {
+	var buf bytes.Buffer
+	opts := &CSVOptions{
+		Columns: []CSVColumn{
+			{Header: "Account ID", Field: "id"},
+			{Header: "Holder", Field: "name"},
+			{Header: "Aliases", Field: "alternative_names"},
+		},
+		Comma:               '\t',
+		MultiValueSeparator: "|",
+	}
+	account := &AccountData{
+		ID: testAccountID,
+		Attributes: &AccountAttributes{
+			Name:             []string{"Samantha Holder", "S Holder"},
+			AlternativeNames: []string{"Sam Holder"},
+		},
+	}
+
+	enc := NewCSVEncoder(&buf, opts)
+	if err := enc.Encode(account); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	const want = "Account ID\tHolder\tAliases\n" + testAccountID + "\tSamantha Holder|S Holder\tSam Holder\n"
+	if got := buf.String(); got != want {
+		t.Errorf("CSV output is %q, want %q", got, want)
+	}
+
+	got, err := NewCSVDecoder(&buf, opts).Decode()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(got, account) {
+		t.Errorf("Decode returned %+v, expected %+v", got, account)
+	}
+}
+
+func TestCSVDecoder_reorderedColumns(t *testing.T) {
+	in := "bic,unknown,id,joint_account\nNWBKGB22,x," + testAccountID + ",true\n"
+	got, err := NewCSVDecoder(strings.NewReader(in), nil).Decode()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if got.ID != testAccountID || got.Attributes.Bic != "NWBKGB22" || !got.Attributes.JointAccount {
+		t.Errorf("Decode returned %+v, %+v", got, got.Attributes)
+	}
+}
+
+func TestCSVDecoder_invalidValue(t *testing.T) {
+	in := "id,version\n" + testAccountID + ",abc\n"
+	_, err := NewCSVDecoder(strings.NewReader(in), nil).Decode()
+	if err == nil {
+		t.Errorf("Decode should return an error on invalid version")
+	}
+}
+
+func TestCSVDecoder_invalidUUID(t *testing.T) {
+	in := "id,version\n" + testAccountID + ",1\nnot-a-uuid,1\n"
+	dec := NewCSVDecoder(strings.NewReader(in), nil)
+	if _, err := dec.Decode(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	_, err := dec.Decode()
+	if !errors.Is(err, ErrInvalidUUID) {
+		t.Fatalf("Decode returned %v, want ErrInvalidUUID", err)
+	}
+	if !strings.Contains(err.Error(), `record 2: column "id"`) {
+		t.Errorf("Decode error %q does not name the record and column", err)
+	}
+}
+
+func TestCSVEncoder_unknownField(t *testing.T) {
+	opts := &CSVOptions{Columns: []CSVColumn{{Header: "x", Field: "nope"}}}
+	if err := NewCSVEncoder(&bytes.Buffer{}, opts).Encode(&AccountData{}); err == nil {
+		t.Errorf("Encode should return an error on unknown field")
+	}
+}
+
+func TestNDJSON_roundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewNDJSONEncoder(&buf)
+	for i := 0; i < 2; i++ {
+		if err := enc.Encode(expectedAccount.Data); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+	}
+
+	if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+		t.Errorf("NDJSON output has %d lines, want 2", lines)
+	}
+
+	dec := NewNDJSONDecoder(&buf)
+	for i := 0; i < 2; i++ {
+		got, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if !reflect.DeepEqual(got, expectedAccount.Data) {
+			t.Errorf("Decode returned %+v, expected %+v", got, expectedAccount.Data)
+		}
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("Decode returned %v, expected io.EOF", err)
+	}
+}
+
+func TestAccountsService_Export(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const total = 5
+	mux.HandleFunc("/v1/"+accountsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testQueryParam(t, r, "page[size]", "2")
+		page, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+
+		list := AccountList{Data: []*AccountData{}}
+		for i := page * 2; i < total && i < page*2+2; i++ {
+			list.Data = append(list.Data, &AccountData{ID: UUID(strconv.Itoa(i))})
+		}
+		response, err := json.Marshal(list)
+		if err != nil {
+			t.Errorf("Unexpected error in test data: %v", err)
+		}
+		fmt.Fprint(w, string(response))
+	})
+
+	var buf bytes.Buffer
+	n, err := client.Accounts.Export(ctx, NewNDJSONEncoder(&buf), 2)
+	if err != nil {
+		t.Errorf("Accounts.Export returned error: %v", err)
+	}
+	if n != total {
+		t.Errorf("Accounts.Export exported %d accounts, expected %d", n, total)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != total {
+		t.Errorf("Export output has %d lines, want %d", lines, total)
+	}
+}
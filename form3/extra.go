@@ -0,0 +1,69 @@
+package form3
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// jsonFields returns the types of the fields of the struct type t by their lower-cased
+// JSON names, including the fields of embedded structs. Fields tagged "-" are skipped.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for embedded, ft := range jsonFields(f.Type) {
+				fields[embedded] = ft
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	return fields
+}
+
+// unknownFields returns the members of the JSON object b that are not in known.
+// Names are matched case-insensitively, as encoding/json does. If there are none, nil is returned.
+func unknownFields(b []byte, known map[string]reflect.Type) (map[string]json.RawMessage, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(b, &object); err != nil {
+		return nil, err
+	}
+	var extra map[string]json.RawMessage
+	for name, value := range object {
+		if _, ok := known[strings.ToLower(name)]; ok {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[name] = value
+	}
+	return extra, nil
+}
+
+// mergeFields adds the members of extra that are not already present to the JSON object b.
+func mergeFields(b []byte, extra map[string]json.RawMessage) ([]byte, error) {
+	if len(extra) == 0 {
+		return b, nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(b, &object); err != nil {
+		return nil, err
+	}
+	for name, value := range extra {
+		if _, ok := object[name]; !ok {
+			object[name] = value
+		}
+	}
+	return json.Marshal(object)
+}
@@ -9,9 +9,11 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
+	"reflect"
 	"strings"
 )
 
@@ -22,15 +24,56 @@ const (
 	baseURLKey      = "FORM3_BASE_URL"
 )
 
+const (
+	// maxDrainBytes limits how much of an unread response body is discarded before closing it.
+	maxDrainBytes = 64 << 10
+	// maxSnippetBytes limits how much of a response body is included in a ResponseDecodeError.
+	maxSnippetBytes = 256
+)
+
+// ErrUnexpectedContentType is wrapped by a ResponseDecodeError when a response body is not JSON.
+var ErrUnexpectedContentType = errors.New("unexpected content type")
+
+// ErrInvalidPathSegment is returned when a value used in a request path is empty or a dot segment.
+var ErrInvalidPathSegment = errors.New("invalid path segment")
+
 // Client manages the communication with the Form3 API.
 type Client struct {
 	// HTTP client used to communicate with the Form3 API.
 	httpClient *http.Client
 	// Base URL for API requests.
 	baseURL *url.URL
+	// organisationID scopes the client to an organisation, see ForOrganisation.
+	organisationID UUID
+	// GenerateIDs makes Create assign a random UUID to resources that are sent without an ID.
+	GenerateIDs bool
+	// DecodeMode selects how strictly responses are checked while decoding, see DecodeStrict.
+	DecodeMode DecodeMode
 	// Accounts holds a reference to an AccountService
 	// which handles the communication with the account related methods of the Form3 API.
 	Accounts *AccountsService
+	// Mandates handles the communication with the direct debit mandate related methods of the Form3 API.
+	Mandates *MandatesService
+	// DirectDebits handles the communication with the direct debit related methods of the Form3 API.
+	DirectDebits *DirectDebitsService
+	// Subscriptions handles the communication with the notification subscription related methods of the Form3 API.
+	Subscriptions *SubscriptionsService
+	// AccountIdentification handles the communication with the Confirmation of Payee methods of the Form3 API.
+	AccountIdentification *AccountIdentificationService
+	// BankDirectory handles the communication with the bank ID and BIC reference data methods of the Form3 API.
+	BankDirectory *BankDirectoryService
+	// Organisations handles the communication with the organisation related methods of the Form3 API.
+	Organisations *OrganisationsService
+	// Users handles the communication with the API user related methods of the Form3 API.
+	Users *UsersService
+	// Roles handles the communication with the role and access control entry related methods of the Form3 API.
+	Roles *RolesService
+	// Audit handles the communication with the audit related methods of the Form3 API.
+	Audit *AuditService
+	// Reports handles the communication with the report related methods of the Form3 API.
+	Reports *ReportsService
+	// Claims handles the communication with the indemnity claim related methods of the Form3 API.
+	Claims *ClaimsService
 }
 
 // service is a type that holds a reference to a Client and allows unified way of managing services.
@@ -39,14 +82,17 @@ type service struct {
 }
 
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
-// pointed to by v, or returned as an error if an API error has occurred.
+// pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer
+// interface, the raw response body will be written to v, without attempting to first decode it.
+// Empty bodies, e.g. of a 204 No Content response, leave v unchanged. A body that cannot be decoded
+// is reported as a *ResponseDecodeError.
 // The provided ctx must be non-nil, if it is nil an error is returned. If it is canceled or times out,
 // ctx.Err() will be returned.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
 	if ctx == nil {
 		return nil, errors.New("context should not be nil")
 	}
-	req.WithContext(ctx)
+	req = req.WithContext(ctx)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -61,6 +107,8 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 	}
 
 	defer func() {
+		// Drain what is left of the body, so the connection can be reused.
+		_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxDrainBytes))
 		if err := resp.Body.Close(); err != nil {
 			log.Printf("WARNING: could not close body %v", err)
 		}
@@ -70,8 +118,12 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 		return resp, err
 	}
 
-	if v != nil {
-		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+	if w, ok := v.(io.Writer); ok {
+		if _, err := io.Copy(w, resp.Body); err != nil {
+			return resp, err
+		}
+	} else if v != nil && resp.StatusCode != http.StatusNoContent && resp.ContentLength != 0 {
+		if err := c.decode(resp, v); err != nil {
 			return resp, err
 		}
 	}
@@ -79,6 +131,67 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 	return resp, nil
 }
 
+// decode decodes the JSON document in the body of resp into v and checks it according to c.DecodeMode.
+// An empty body leaves v unchanged. Failures are returned as a *ResponseDecodeError.
+func (c *Client) decode(resp *http.Response, v interface{}) error {
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return newResponseDecodeError(resp, data, err)
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil
+	}
+	// Bodies that do not start like a JSON document are only decoded if they are declared as JSON,
+	// so that e.g. an HTML error page of a proxy is reported as such rather than as a syntax error.
+	if trimmed[0] != '{' && trimmed[0] != '[' && !isJSONContentType(resp.Header.Get("Content-Type")) {
+		return newResponseDecodeError(resp, data, fmt.Errorf("%w %q", ErrUnexpectedContentType, resp.Header.Get("Content-Type")))
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		if c.DecodeMode != DecodeDefault {
+			err = decodeError(data, reflect.TypeOf(v), err)
+		}
+		return newResponseDecodeError(resp, data, err)
+	}
+	if c.DecodeMode == DecodeDefault {
+		return nil
+	}
+
+	problems := checkDocument(data, reflect.TypeOf(v))
+	if len(problems) == 0 {
+		return nil
+	}
+	if c.DecodeMode == DecodeStrict {
+		return newResponseDecodeError(resp, data, problems[0])
+	}
+	for _, problem := range problems {
+		log.Printf("WARNING: %v", problem)
+	}
+	return nil
+}
+
+// isJSONContentType reports whether the media type of contentType is JSON, e.g. application/vnd.api+json.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// DoRaw sends an API request and returns the undecoded response body, e.g. the
+// raw JSON:API document including members this package does not model.
+// API errors are returned as for Do.
+func (c *Client) DoRaw(ctx context.Context, req *http.Request) (json.RawMessage, *http.Response, error) {
+	buf := new(bytes.Buffer)
+	resp, err := c.Do(ctx, req, buf)
+	if err != nil {
+		return nil, resp, err
+	}
+	return json.RawMessage(buf.Bytes()), resp, nil
+}
+
 // NewRequest creates an API request. A relative URL can be provided in path,
 // in which case it is resolved relative to the BaseURL of the Client.
 // Relative URLs should always be specified without a preceding slash. If
@@ -96,6 +209,13 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 		if err := json.NewEncoder(buf).Encode(body); err != nil {
 			return nil, err
 		}
+		if method == http.MethodPost && c.organisationID != "" {
+			stamped, err := stampOrganisation(buf.(*bytes.Buffer).Bytes(), c.organisationID)
+			if err != nil {
+				return nil, err
+			}
+			buf = bytes.NewBuffer(stamped)
+		}
 	}
 
 	req, err := http.NewRequest(method, requestURL.String(), buf)
@@ -110,6 +230,70 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 	return req, nil
 }
 
+// stampOrganisation sets the organisation_id of the resource in the JSON:API
+// document body to organisationID, unless the resource already names one.
+// Bodies without a data object are returned unchanged.
+func stampOrganisation(body []byte, organisationID UUID) ([]byte, error) {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(body, &document); err != nil {
+		return body, nil
+	}
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(document["data"], &data); err != nil || data == nil {
+		return body, nil
+	}
+
+	var current string
+	if raw, ok := data["organisation_id"]; ok {
+		_ = json.Unmarshal(raw, &current)
+	}
+	if current != "" {
+		return body, nil
+	}
+
+	id, err := json.Marshal(organisationID)
+	if err != nil {
+		return nil, err
+	}
+	data["organisation_id"] = id
+	if document["data"], err = json.Marshal(data); err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
+
+// listPath returns the path to a page of the resources at base. If the client
+// is scoped to an organisation, the list is filtered to that organisation.
+func (c *Client) listPath(base string, pageNumber int, pageSize int) string {
+	path := pagePath(base, pageNumber, pageSize)
+	if c.organisationID != "" {
+		path += "&filter[organisation_id]=" + url.QueryEscape(c.organisationID.String())
+	}
+	return path
+}
+
+// pagePath returns the path to a page of the resources at base, without any filter.
+func pagePath(base string, pageNumber int, pageSize int) string {
+	return fmt.Sprintf("%s?page[number]=%d&page[size]=%d", base, pageNumber, pageSize)
+}
+
+// joinPath appends the given segments to the trusted base path.
+// Each segment is escaped, so values containing '/', '?' or '#' cannot change
+// the request target. Empty segments and the dot segments "." and ".." are
+// rejected with an error wrapping ErrInvalidPathSegment.
+func joinPath(base string, segments ...string) (string, error) {
+	var b strings.Builder
+	b.WriteString(base)
+	for _, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", fmt.Errorf("%w: %q", ErrInvalidPathSegment, segment)
+		}
+		b.WriteString("/")
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String(), nil
+}
+
 // CheckResponse checks the API response for errors, and returns them if
 // present. A response is considered an error if it has a status code outside
 // the 200 range. API error responses are expected to have either no response
@@ -132,6 +316,34 @@ func CheckResponse(r *http.Response) error {
 	return errorResponse
 }
 
+// ResponseDecodeError is returned by Do when the body of a successful response cannot be decoded.
+type ResponseDecodeError struct {
+	Response *http.Response // HTTP response whose body could not be decoded
+	// Body holds the start of the response body, truncated to 256 bytes.
+	Body string
+	Err  error
+}
+
+// newResponseDecodeError returns a ResponseDecodeError for resp with the start of body.
+func newResponseDecodeError(resp *http.Response, body []byte, err error) *ResponseDecodeError {
+	if len(body) > maxSnippetBytes {
+		body = append(body[:maxSnippetBytes:maxSnippetBytes], "..."...)
+	}
+	return &ResponseDecodeError{Response: resp, Body: string(body), Err: err}
+}
+
+// Error returns a string representation of a response decoding error
+func (e *ResponseDecodeError) Error() string {
+	return fmt.Sprintf("%v %v: %d: could not decode body %q: %v",
+		e.Response.Request.Method, e.Response.Request.URL,
+		e.Response.StatusCode, e.Body, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ResponseDecodeError) Unwrap() error {
+	return e.Err
+}
+
 // ErrorResponse represents an error caused by an API request
 type ErrorResponse struct {
 	Response     *http.Response // HTTP response that caused this error
@@ -171,10 +383,48 @@ func NewClient(baseURL string, httpClient *http.Client) (*Client, error) {
 		httpClient: httpClient,
 		baseURL:    parsedURL,
 	}
-	c.Accounts = &AccountsService{client: c}
+	c.initServices()
 	return c, nil
 }
 
+// initServices creates the services of c.
+func (c *Client) initServices() {
+	c.Accounts = &AccountsService{resources: NewResourceService[AccountData](c, accountsPath)}
+	c.Mandates = &MandatesService{client: c}
+	c.DirectDebits = &DirectDebitsService{client: c}
+	c.Subscriptions = &SubscriptionsService{client: c}
+	c.AccountIdentification = &AccountIdentificationService{client: c}
+	c.BankDirectory = &BankDirectoryService{client: c, cache: newTTLCache(0)}
+	c.Organisations = &OrganisationsService{client: c}
+	c.Users = &UsersService{client: c}
+	c.Roles = &RolesService{client: c}
+	c.Audit = &AuditService{client: c}
+	c.Reports = &ReportsService{client: c}
+	c.Claims = &ClaimsService{client: c}
+}
+
+// ForOrganisation returns a view of the client scoped to the given organisation.
+// Resources created through the view get organisationID stamped into their
+// organisation_id unless they already name one, and lists are filtered to the organisation.
+// The view shares the HTTP client and the bank directory cache with c.
+func (c *Client) ForOrganisation(organisationID UUID) *Client {
+	scoped := &Client{
+		httpClient:     c.httpClient,
+		baseURL:        c.baseURL,
+		organisationID: organisationID,
+		GenerateIDs:    c.GenerateIDs,
+		DecodeMode:     c.DecodeMode,
+	}
+	scoped.initServices()
+	scoped.BankDirectory.cache = c.BankDirectory.cache
+	return scoped
+}
+
+// OrganisationID returns the organisation the client is scoped to, or an empty UUID if it is not scoped.
+func (c *Client) OrganisationID() UUID {
+	return c.organisationID
+}
+
 // NewClientFromEnvironment returns a new Form3 API client.
 // The baseURL to the API will be read from the environment and default http client will be used.
 // Users who wish to pass their own http.Client should use NewClient() method.
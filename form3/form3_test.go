@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -11,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -60,6 +63,15 @@ func testBody(t *testing.T, r *http.Request, v io.Reader) {
 	}
 }
 
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	response, err := json.Marshal(v)
+	if err != nil {
+		t.Errorf("Unexpected error in test data: %v", err)
+	}
+	w.Header().Set("Content-Type", applicationJson)
+	fmt.Fprint(w, string(response))
+}
+
 func TestNewClient_appendsV1(t *testing.T) {
 	const baseURL = "http://localhost"
 	c, err := NewClient(baseURL, nil)
@@ -144,3 +156,160 @@ func TestNewRequest(t *testing.T) {
 		t.Errorf("Request URL is %v, want %v", got, wantUrl)
 	}
 }
+
+func TestDo_appliesContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/slow", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	request, _ := client.NewRequest(http.MethodGet, "slow", nil)
+	if _, err := client.Do(canceled, request, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Do returned %v, want context.Canceled", err)
+	}
+}
+
+func TestDo_writesToWriter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const body = "not,json\n1,2\n"
+	mux.HandleFunc("/v1/file", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	})
+
+	request, _ := client.NewRequest(http.MethodGet, "file", nil)
+	var buf bytes.Buffer
+	if _, err := client.Do(ctx, request, &buf); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if buf.String() != body {
+		t.Errorf("Do wrote %q, want %q", buf.String(), body)
+	}
+}
+
+func TestJoinPath(t *testing.T) {
+	tests := []struct {
+		segments []string
+		want     string
+	}{
+		{segments: nil, want: "base"},
+		{segments: []string{"1"}, want: "base/1"},
+		{segments: []string{"a/b"}, want: "base/a%2Fb"},
+		{segments: []string{"a?version=1"}, want: "base/a%3Fversion=1"},
+		{segments: []string{"a#b", "c"}, want: "base/a%23b/c"},
+		{segments: []string{"..."}, want: "base/..."},
+	}
+
+	for _, tt := range tests {
+		got, err := joinPath("base", tt.segments...)
+		if err != nil {
+			t.Errorf("joinPath(%q) returned error %v", tt.segments, err)
+		}
+		if got != tt.want {
+			t.Errorf("joinPath(%q) is %v, want %v", tt.segments, got, tt.want)
+		}
+	}
+}
+
+func TestJoinPath_rejectsSegments(t *testing.T) {
+	for _, segment := range []string{"", ".", ".."} {
+		if _, err := joinPath("base", "1", segment); !errors.Is(err, ErrInvalidPathSegment) {
+			t.Errorf("joinPath(%q) returned %v, want ErrInvalidPathSegment", segment, err)
+		}
+	}
+}
+
+func TestDoRaw(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const document = `{"data":[],"links":{"self":"/v1/test"}}`
+	mux.HandleFunc("/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, document)
+	})
+
+	request, _ := client.NewRequest(http.MethodGet, "test", nil)
+	raw, _, err := client.DoRaw(ctx, request)
+	if err != nil {
+		t.Fatalf("DoRaw returned error: %v", err)
+	}
+	if string(raw) != document {
+		t.Errorf("DoRaw returned %s, want %s", raw, document)
+	}
+}
+
+func TestDo_emptyBodies(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/no-content", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/v1/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", applicationJson)
+		fmt.Fprint(w, "\n")
+	})
+	mux.HandleFunc("/v1/no-body", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "0")
+	})
+
+	for _, path := range []string{"no-content", "empty", "no-body"} {
+		request, _ := client.NewRequest(http.MethodDelete, path, nil)
+		account := new(Account)
+		if _, err := client.Do(ctx, request, account); err != nil {
+			t.Errorf("Do(%s) returned error: %v", path, err)
+		}
+		if account.Data != nil {
+			t.Errorf("Do(%s) decoded %+v, want no data", path, account.Data)
+		}
+	}
+}
+
+func TestDo_decodeErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	page := "<html><body>" + strings.Repeat("Bad gateway ", 50) + "</body></html>"
+	mux.HandleFunc("/v1/html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, page)
+	})
+	mux.HandleFunc("/v1/truncated", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", applicationJson)
+		w.Header().Set("Content-Length", "100")
+		fmt.Fprint(w, `{"data":{"type":"acc`)
+	})
+	mux.HandleFunc("/v1/invalid", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", applicationJson)
+		fmt.Fprint(w, `{"data":`)
+	})
+
+	tests := []struct {
+		path string
+		want error
+	}{
+		{path: "html", want: ErrUnexpectedContentType},
+		{path: "truncated", want: io.ErrUnexpectedEOF},
+		{path: "invalid"},
+	}
+	for _, tt := range tests {
+		request, _ := client.NewRequest(http.MethodGet, tt.path, nil)
+		_, err := client.Do(ctx, request, new(Account))
+
+		var decodeErr *ResponseDecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Fatalf("Do(%s) returned %v, want ResponseDecodeError", tt.path, err)
+		}
+		if decodeErr.Response.StatusCode != http.StatusOK || len(decodeErr.Body) > maxSnippetBytes+3 {
+			t.Errorf("Do(%s) returned status %d and body %q", tt.path, decodeErr.Response.StatusCode, decodeErr.Body)
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("Do(%s) returned %v, want %v", tt.path, err, tt.want)
+		}
+	}
+}
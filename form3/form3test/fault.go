@@ -0,0 +1,237 @@
+// Package form3test provides utilities for testing code that uses the form3 client.
+package form3test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Matcher selects the requests a fault is injected into.
+type Matcher func(req *http.Request) bool
+
+// MatchAll matches every request.
+func MatchAll() Matcher {
+	return func(*http.Request) bool { return true }
+}
+
+// MatchMethod matches requests with the given HTTP method.
+func MatchMethod(method string) Matcher {
+	return func(req *http.Request) bool { return req.Method == method }
+}
+
+// MatchPath matches requests whose URL path contains substr, e.g. "organisation/accounts".
+func MatchPath(substr string) Matcher {
+	return func(req *http.Request) bool { return strings.Contains(req.URL.Path, substr) }
+}
+
+// MatchPathRegexp matches requests whose URL path matches the regular expression expr.
+// It panics if expr cannot be compiled.
+func MatchPathRegexp(expr string) Matcher {
+	re := regexp.MustCompile(expr)
+	return func(req *http.Request) bool { return re.MatchString(req.URL.Path) }
+}
+
+// And matches requests matched by all of matchers.
+func And(matchers ...Matcher) Matcher {
+	return func(req *http.Request) bool {
+		for _, m := range matchers {
+			if !m(req) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Fault produces the outcome of a request it is injected into. next sends the request on to the
+// transport wrapped by the FaultTransport, for faults that alter a real response.
+type Fault func(req *http.Request, next http.RoundTripper) (*http.Response, error)
+
+// Latency delays the request by d before sending it on. The delay ends early if the request is canceled.
+func Latency(d time.Duration) Fault {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-req.Context().Done():
+			closeBody(req)
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		return next.RoundTrip(req)
+	}
+}
+
+// Status responds with the given status code and a Form3 error body, without sending the request.
+func Status(code int) Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		closeBody(req)
+		body := fmt.Sprintf(`{"error_message":"injected %d %s"}`, code, http.StatusText(code))
+		return newResponse(req, code, body), nil
+	}
+}
+
+// TooManyRequests responds with 429 Too Many Requests and a Retry-After header of retryAfter, rounded to seconds.
+func TooManyRequests(retryAfter time.Duration) Fault {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		resp, _ := Status(http.StatusTooManyRequests)(req, next)
+		resp.Header.Set("Retry-After", strconv.Itoa(int(retryAfter.Round(time.Second)/time.Second)))
+		return resp, nil
+	}
+}
+
+// ConnectionReset fails the request with a connection reset error, without sending it.
+func ConnectionReset() Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		closeBody(req)
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	}
+}
+
+// MalformedJSON sends the request on and truncates the body of the response to half its length,
+// so that it is no longer valid JSON.
+func MalformedJSON() Fault {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(body) < 2 {
+			body = []byte(`{"data":`)
+		} else {
+			body = body[:len(body)/2]
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		resp.ContentLength = int64(len(body))
+		resp.Header.Del("Content-Length")
+		return resp, nil
+	}
+}
+
+// closeBody closes the body of a request that is not sent on, as http.RoundTripper requires.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
+
+// rule injects fault into the requests selected by match with the given probability.
+type rule struct {
+	match       Matcher
+	probability float64
+	fault       Fault
+}
+
+// FaultTransport is an http.RoundTripper that injects faults into requests, for use as
+// the Transport of the http.Client passed to form3.NewClient:
+//
+//	faults := form3test.NewFaultTransport(nil)
+//	faults.Inject(form3test.MatchMethod(http.MethodPost), 0.2, form3test.Status(http.StatusServiceUnavailable))
+//	client, err := form3.NewClient(baseURL, &http.Client{Transport: faults})
+//
+// Rules are tried in the order they were added; the first one that matches a request and
+// fires according to its probability decides its outcome. Requests without a fault are sent on unchanged.
+// It is safe for concurrent use.
+type FaultTransport struct {
+	base http.RoundTripper
+
+	mu       sync.Mutex
+	rules    []rule
+	rand     *rand.Rand
+	injected int
+}
+
+// NewFaultTransport returns a FaultTransport sending requests on to base.
+// If base is nil, http.DefaultTransport is used.
+func NewFaultTransport(base http.RoundTripper) *FaultTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &FaultTransport{base: base, rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// Inject adds a rule injecting fault into requests selected by match with the given probability,
+// between 0 and 1. It returns t to allow chaining.
+func (t *FaultTransport) Inject(match Matcher, probability float64, fault Fault) *FaultTransport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rules = append(t.rules, rule{match: match, probability: probability, fault: fault})
+	return t
+}
+
+// Seed makes the probabilistic choices of t reproducible.
+func (t *FaultTransport) Seed(seed int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rand = rand.New(rand.NewSource(seed))
+}
+
+// Reset removes all rules and resets the count of injected faults.
+func (t *FaultTransport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rules, t.injected = nil, 0
+}
+
+// Injected returns the number of requests a fault was injected into.
+func (t *FaultTransport) Injected() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.injected
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if fault := t.choose(req); fault != nil {
+		return fault(req, t.base)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// choose returns the fault to inject into req, or nil.
+func (t *FaultTransport) choose(req *http.Request) Fault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, r := range t.rules {
+		if !r.match(req) {
+			continue
+		}
+		if r.probability >= 1 || t.rand.Float64() < r.probability {
+			t.injected++
+			return r.fault
+		}
+	}
+	return nil
+}
+
+// newResponse returns a JSON response to req with the given status code and body.
+func newResponse(req *http.Request, code int, body string) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(code) + " " + http.StatusText(code),
+		StatusCode:    code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
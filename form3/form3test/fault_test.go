@@ -0,0 +1,129 @@
+package form3test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/martoup/go-form3/form3"
+)
+
+const testAccountID = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+
+func setup(t *testing.T) (*form3.Client, *FaultTransport) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"type":"accounts","id":"`+testAccountID+`","version":0}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	faults := NewFaultTransport(nil)
+	client, err := form3.NewClient(server.URL, &http.Client{Transport: faults})
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	return client, faults
+}
+
+func TestFaultTransport_faults(t *testing.T) {
+	ctx := context.Background()
+	client, faults := setup(t)
+
+	faults.Inject(MatchPath("organisation/accounts"), 1, Status(http.StatusServiceUnavailable))
+	_, _, err := client.Accounts.Fetch(ctx, testAccountID)
+	var errorResponse *form3.ErrorResponse
+	if !errors.As(err, &errorResponse) || errorResponse.Response.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Fetch returned %v, want 503 ErrorResponse", err)
+	}
+
+	faults.Reset()
+	faults.Inject(MatchAll(), 1, TooManyRequests(2*time.Second))
+	_, _, err = client.Accounts.Fetch(ctx, testAccountID)
+	if !errors.As(err, &errorResponse) || errorResponse.Response.Header.Get("Retry-After") != "2" {
+		t.Errorf("Fetch returned %v, want 429 with Retry-After", err)
+	}
+
+	faults.Reset()
+	faults.Inject(MatchMethod(http.MethodGet), 1, ConnectionReset())
+	if _, _, err = client.Accounts.Fetch(ctx, testAccountID); !errors.Is(err, syscall.ECONNRESET) {
+		t.Errorf("Fetch returned %v, want ECONNRESET", err)
+	}
+
+	faults.Reset()
+	faults.Inject(MatchAll(), 1, MalformedJSON())
+	var decodeErr *form3.ResponseDecodeError
+	if _, _, err = client.Accounts.Fetch(ctx, testAccountID); !errors.As(err, &decodeErr) {
+		t.Errorf("Fetch returned %v, want ResponseDecodeError", err)
+	}
+	if faults.Injected() != 1 {
+		t.Errorf("Injected is %d, want 1", faults.Injected())
+	}
+}
+
+// trackedBody is a request body that records whether it was closed.
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestFaultTransport_closesRequestBody(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	faults := map[string]Fault{
+		"Status":          Status(http.StatusServiceUnavailable),
+		"TooManyRequests": TooManyRequests(time.Second),
+		"ConnectionReset": ConnectionReset(),
+		"Latency":         Latency(time.Second),
+	}
+	for name, fault := range faults {
+		body := &trackedBody{Reader: strings.NewReader(`{"data":{}}`)}
+		req := httptest.NewRequest(http.MethodPost, "http://localhost/v1/organisation/accounts", body).WithContext(canceled)
+		req.Body = body
+		if resp, _ := NewFaultTransport(nil).Inject(MatchAll(), 1, fault).RoundTrip(req); resp != nil {
+			resp.Body.Close()
+		}
+		if !body.closed {
+			t.Errorf("%s did not close the request body", name)
+		}
+	}
+}
+
+func TestFaultTransport_latency(t *testing.T) {
+	client, faults := setup(t)
+	faults.Inject(MatchPathRegexp(`/accounts/[^/]+$`), 1, Latency(time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := client.Accounts.Fetch(ctx, testAccountID); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Fetch returned %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestFaultTransport_probability(t *testing.T) {
+	client, faults := setup(t)
+	faults.Seed(1)
+	faults.Inject(And(MatchAll(), MatchMethod(http.MethodGet)), 0.3, Status(http.StatusInternalServerError))
+
+	const requests = 200
+	failed := 0
+	for i := 0; i < requests; i++ {
+		if _, _, err := client.Accounts.Fetch(context.Background(), testAccountID); err != nil {
+			failed++
+		}
+	}
+	if failed != faults.Injected() || failed < requests/10 || failed > requests/2 {
+		t.Errorf("%d of %d requests failed with %d injected faults, want about 30%%", failed, requests, faults.Injected())
+	}
+}
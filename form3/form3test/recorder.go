@@ -0,0 +1,316 @@
+package form3test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnmatchedRequest is returned by a replaying Recorder for a request without a recorded interaction.
+var ErrUnmatchedRequest = errors.New("no recorded interaction matches request")
+
+// Mode selects whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeReplay serves requests from the fixture file without contacting the API.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the API and records the interactions, see Recorder.Stop.
+	ModeRecord
+)
+
+// recordEnv is the environment variable that makes ModeFromEnvironment select ModeRecord.
+const recordEnv = "FORM3_RECORD"
+
+// ModeFromEnvironment returns ModeRecord if the FORM3_RECORD environment variable is set to
+// a true value, e.g. when running against the accountapi of docker-compose, and ModeReplay otherwise.
+func ModeFromEnvironment() Mode {
+	if record, _ := strconv.ParseBool(os.Getenv(recordEnv)); record {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// Interaction is a recorded request and its response, as stored in a fixture file.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the scrubbed request of an Interaction.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	// URL holds the path and query of the request.
+	URL  string `json:"url"`
+	Body string `json:"body,omitempty"`
+}
+
+// RecordedResponse is the scrubbed response of an Interaction.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// RecorderOptions configures a Recorder. Zero values select the defaults.
+type RecorderOptions struct {
+	// Mode defaults to ModeReplay.
+	Mode Mode
+	// Base is the transport requests are sent with while recording. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+	// SecretFields are the names of JSON members whose values are replaced by "REDACTED".
+	// Defaults to password, secret, client_secret, token and private_key.
+	SecretFields []string
+}
+
+var (
+	uuidPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	// timestampPattern matches timestamps with and without a time zone, as accepted by form3.Timestamp.
+	timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})?`)
+	// recordedHeaders are the response headers kept in fixtures; all others may be volatile or secret.
+	recordedHeaders = []string{"Content-Type", "Etag", "Cache-Control", "Retry-After", "Location"}
+)
+
+// fixedTimestamp replaces every timestamp in fixtures.
+const fixedTimestamp = "2000-01-01T00:00:00.000Z"
+
+// Recorder is an http.RoundTripper that records interactions with the API to a fixture file
+// and replays them, for use as the Transport of the http.Client passed to form3.NewClient:
+//
+//	recorder, err := form3test.NewRecorder("testdata/accounts.json", &form3test.RecorderOptions{Mode: form3test.ModeFromEnvironment()})
+//	client, err := form3.NewClient(baseURL, &http.Client{Transport: recorder})
+//	defer recorder.Stop()
+//
+// Fixtures are scrubbed: response headers other than a few stable ones are dropped, values of
+// secret JSON members are redacted, timestamps are fixed, and UUIDs are replaced by placeholders
+// numbered in order of appearance. While replaying, the UUIDs of requests are mapped onto the
+// placeholders in the same order and back in responses, so tests that generate random IDs replay
+// deterministically. Requests are matched on method, URL and JSON body; each recorded
+// interaction is replayed once, in order. It is safe for concurrent use.
+type Recorder struct {
+	path    string
+	opts    RecorderOptions
+	secrets *regexp.Regexp
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+	// placeholders maps real UUIDs onto placeholders, and ids maps them back.
+	placeholders map[string]string
+	ids          map[string]string
+}
+
+// NewRecorder returns a Recorder for the fixture file at path. In ModeReplay the file is read
+// immediately; in ModeRecord it is written by Stop. If opts is nil, the defaults are used.
+func NewRecorder(path string, opts *RecorderOptions) (*Recorder, error) {
+	r := &Recorder{path: path, placeholders: make(map[string]string), ids: make(map[string]string)}
+	if opts != nil {
+		r.opts = *opts
+	}
+	if r.opts.Base == nil {
+		r.opts.Base = http.DefaultTransport
+	}
+	if r.opts.SecretFields == nil {
+		r.opts.SecretFields = []string{"password", "secret", "client_secret", "token", "private_key"}
+	}
+	names := make([]string, len(r.opts.SecretFields))
+	for i, name := range r.opts.SecretFields {
+		names[i] = regexp.QuoteMeta(name)
+	}
+	r.secrets = regexp.MustCompile(`("(?:` + strings.Join(names, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+	if r.opts.Mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("reading fixture %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.interactions))
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.opts.Mode == ModeRecord {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+// record sends req and appends the scrubbed interaction.
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.opts.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	header := make(http.Header)
+	for _, name := range recordedHeaders {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			header[name] = values
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    r.scrub(req.URL.RequestURI()),
+			Body:   r.scrub(string(body)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       r.scrub(string(respBody)),
+		},
+	})
+	return resp, nil
+}
+
+// replay serves req from the first unused matching interaction.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    r.scrub(req.URL.RequestURI()),
+		Body:   r.scrub(string(body)),
+	}
+	for i, interaction := range r.interactions {
+		if r.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		respBody := r.restore(interaction.Response.Body)
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			Status:        strconv.Itoa(interaction.Response.StatusCode) + " " + http.StatusText(interaction.Response.StatusCode),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s %s", ErrUnmatchedRequest, recorded.Method, recorded.URL, recorded.Body)
+}
+
+// Unused returns the recorded interactions that have not been replayed.
+func (r *Recorder) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []*Interaction
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// Stop writes the recorded interactions to the fixture file in ModeRecord. It does nothing in ModeReplay.
+func (r *Recorder) Stop() error {
+	if r.opts.Mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interactions := r.interactions
+	if interactions == nil {
+		interactions = []*Interaction{}
+	}
+	data, err := json.MarshalIndent(interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// scrub redacts secrets, fixes timestamps and replaces UUIDs by placeholders in s.
+// Callers must hold r.mu.
+func (r *Recorder) scrub(s string) string {
+	s = r.secrets.ReplaceAllString(s, `$1"REDACTED"`)
+	s = timestampPattern.ReplaceAllString(s, fixedTimestamp)
+	return uuidPattern.ReplaceAllStringFunc(s, r.placeholder)
+}
+
+// placeholder returns the placeholder of id, assigning the next one if id has none.
+// Callers must hold r.mu.
+func (r *Recorder) placeholder(id string) string {
+	id = strings.ToLower(id)
+	if p, ok := r.placeholders[id]; ok {
+		return p
+	}
+	p := fmt.Sprintf("00000000-0000-4000-8000-%012d", len(r.placeholders)+1)
+	r.placeholders[id] = p
+	r.ids[p] = id
+	return p
+}
+
+// restore replaces the placeholders in a recorded response body by the UUIDs they stand for.
+// Placeholders no request has used yet, e.g. of resources created by the API, stand for themselves.
+// Callers must hold r.mu.
+func (r *Recorder) restore(s string) string {
+	return uuidPattern.ReplaceAllStringFunc(s, func(p string) string {
+		if id, ok := r.ids[p]; ok {
+			return id
+		}
+		r.placeholders[p] = p
+		r.ids[p] = p
+		return p
+	})
+}
+
+// matches reports whether the recorded request matches req, comparing JSON bodies by value.
+func matches(recorded RecordedRequest, req RecordedRequest) bool {
+	if recorded.Method != req.Method || recorded.URL != req.URL {
+		return false
+	}
+	if recorded.Body == req.Body {
+		return true
+	}
+	var a, b interface{}
+	if json.Unmarshal([]byte(recorded.Body), &a) != nil || json.Unmarshal([]byte(req.Body), &b) != nil {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
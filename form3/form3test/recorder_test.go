@@ -0,0 +1,103 @@
+package form3test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/martoup/go-form3/form3"
+)
+
+// accountsScenario creates an account with a random ID and a user whose credentials the API returns.
+func accountsScenario(t *testing.T, client *form3.Client) {
+	ctx := context.Background()
+	client.GenerateIDs = true
+
+	account := &form3.Account{Data: &form3.AccountData{Type: "accounts", Attributes: &form3.AccountAttributes{Country: "GB"}}}
+	created, _, err := client.Accounts.Create(ctx, account)
+	if err != nil {
+		t.Fatalf("Accounts.Create returned error: %v", err)
+	}
+	if created.Data.ID != account.Data.ID || created.Data.CreatedOn == nil {
+		t.Errorf("Accounts.Create returned %+v, sent ID %s", created.Data, account.Data.ID)
+	}
+
+	fetched, _, err := client.Accounts.Fetch(ctx, account.Data.ID.String())
+	if err != nil {
+		t.Fatalf("Accounts.Fetch returned error: %v", err)
+	}
+	if fetched.Data.ID != account.Data.ID {
+		t.Errorf("Accounts.Fetch returned ID %s, want %s", fetched.Data.ID, account.Data.ID)
+	}
+}
+
+func TestRecorder_recordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var doc struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if r.Method == http.MethodPost {
+			_ = json.NewDecoder(r.Body).Decode(&doc)
+		} else {
+			doc.Data = map[string]interface{}{"id": filepath.Base(r.URL.Path)}
+		}
+		doc.Data["created_on"] = "2021-03-04T05:06:07.089Z"
+		doc.Data["modified_on"] = "2021-03-05T05:06:07.089"
+		doc.Data["secret"] = "s3cr3t"
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		_ = json.NewEncoder(w).Encode(doc)
+	}))
+	fixture := filepath.Join(t.TempDir(), "accounts.json")
+
+	recorder, err := NewRecorder(fixture, &RecorderOptions{Mode: ModeRecord})
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	client, _ := form3.NewClient(server.URL, &http.Client{Transport: recorder})
+	accountsScenario(t, client)
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	server.Close()
+
+	data, _ := ioutil.ReadFile(fixture)
+	for _, leaked := range []string{"s3cr3t", "session=abc", "2021-03-04", "2021-03-05"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("Fixture contains %q:\n%s", leaked, data)
+		}
+	}
+
+	recorder, err = NewRecorder(fixture, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	client, _ = form3.NewClient(server.URL, &http.Client{Transport: recorder})
+	accountsScenario(t, client)
+	if unused := recorder.Unused(); len(unused) != 0 {
+		t.Errorf("Replay left %d interactions unused", len(unused))
+	}
+}
+
+func TestRecorder_unmatchedRequest(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "empty.json")
+	if err := ioutil.WriteFile(fixture, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder, err := NewRecorder(fixture, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	client, _ := form3.NewClient("http://localhost", &http.Client{Transport: recorder})
+	_, _, err = client.Accounts.Fetch(context.Background(), testAccountID)
+	if !errors.Is(err, ErrUnmatchedRequest) {
+		t.Errorf("Fetch returned %v, want ErrUnmatchedRequest", err)
+	}
+}
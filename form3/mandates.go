@@ -0,0 +1,258 @@
+package form3
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// mandatesPath URL path to mandate resources.
+	mandatesPath = "transaction/mandates"
+	// submissionsPath URL path segment of submission sub-resources.
+	submissionsPath = "submissions"
+	// returnsPath URL path segment of return sub-resources.
+	returnsPath = "returns"
+)
+
+// MandateStatus is the processing status of a mandate, a submission or a return.
+type MandateStatus string
+
+// Statuses of mandates and their submissions and returns.
+const (
+	StatusPending   MandateStatus = "pending"
+	StatusAccepted  MandateStatus = "accepted"
+	StatusConfirmed MandateStatus = "confirmed"
+	StatusRejected  MandateStatus = "rejected"
+	StatusFailed    MandateStatus = "failed"
+)
+
+// Mandate represents a direct debit mandate registered with Form3.
+type Mandate struct {
+	Data *MandateData `json:"data"`
+}
+
+// MandateList represents a list of direct debit mandates.
+type MandateList struct {
+	Data []*MandateData `json:"data"`
+}
+
+// MandateData represents the main attributes for a given mandate.
+type MandateData struct {
+	ResourceData
+	Attributes *MandateAttributes `json:"attributes"`
+}
+
+// MandateAttributes represents the available mandate attribute fields.
+type MandateAttributes struct {
+	// Reference is the mandate reference agreed with the debtor.
+	Reference        string        `json:"reference"`
+	Scheme           Scheme        `json:"scheme,omitempty"`
+	Currency         string        `json:"currency,omitempty"`
+	DebtorParty      *Party        `json:"debtor_party,omitempty"`
+	BeneficiaryParty *Party        `json:"beneficiary_party,omitempty"`
+	SigningDate      string        `json:"signing_date,omitempty"`
+	Status           MandateStatus `json:"status,omitempty"`
+}
+
+// MandateSubmission represents the submission of a mandate to the scheme.
+type MandateSubmission struct {
+	Data *MandateSubmissionData `json:"data"`
+}
+
+// MandateSubmissionData represents the main attributes for a given mandate submission.
+type MandateSubmissionData struct {
+	ResourceData
+	Attributes *SubmissionAttributes `json:"attributes,omitempty"`
+}
+
+// SubmissionAttributes represents the status of a submission to a scheme.
+type SubmissionAttributes struct {
+	Status             MandateStatus `json:"status,omitempty"`
+	StatusReason       string        `json:"status_reason,omitempty"`
+	SchemeStatusCode   string        `json:"scheme_status_code,omitempty"`
+	SubmissionDateTime *Timestamp    `json:"submission_datetime,omitempty"`
+}
+
+// MandateReturn represents the return (cancellation by the debtor bank) of a mandate.
+type MandateReturn struct {
+	Data *MandateReturnData `json:"data"`
+}
+
+// MandateReturnData represents the main attributes for a given mandate return.
+type MandateReturnData struct {
+	ResourceData
+	Attributes *ReturnAttributes `json:"attributes"`
+}
+
+// ReturnAttributes represents the reason and status of a return.
+type ReturnAttributes struct {
+	ReturnCode       string        `json:"return_code"`
+	SchemeReturnCode string        `json:"scheme_return_code,omitempty"`
+	Status           MandateStatus `json:"status,omitempty"`
+}
+
+// MandatesService handles the communication with the mandate related
+// methods of the Form3 API.
+//
+// Form3 API docs: https://api-docs.form3.tech/api.html?http#mandates
+type MandatesService service
+
+// Create creates a new mandate.
+// If Client.GenerateIDs is set and the mandate has no ID, a random one is assigned to mandate before sending.
+func (s *MandatesService) Create(ctx context.Context, mandate *Mandate) (*Mandate, *http.Response, error) {
+	if mandate != nil {
+		generateID(s.client, mandate.Data)
+	}
+	request, err := s.client.NewRequest(http.MethodPost, mandatesPath, mandate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(Mandate)
+	resp, err := s.client.Do(ctx, request, m)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return m, resp, nil
+}
+
+// Fetch gets a single mandate using the mandate ID.
+func (s *MandatesService) Fetch(ctx context.Context, mandateID string) (*Mandate, *http.Response, error) {
+	if err := validateIDs(mandateID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(mandatesPath, mandateID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(Mandate)
+	resp, err := s.client.Do(ctx, request, m)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return m, resp, nil
+}
+
+// List lists all mandates. Supports pagination.
+func (s *MandatesService) List(ctx context.Context, pageNumber int, pageSize int) (*MandateList, *http.Response, error) {
+	path := s.client.listPath(mandatesPath, pageNumber, pageSize)
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(MandateList)
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, nil
+}
+
+// CreateSubmission submits a mandate to the scheme.
+// If Client.GenerateIDs is set and the submission has no ID, a random one is assigned to submission before sending.
+func (s *MandatesService) CreateSubmission(ctx context.Context, mandateID string, submission *MandateSubmission) (*MandateSubmission, *http.Response, error) {
+	if err := validateIDs(mandateID); err != nil {
+		return nil, nil, err
+	}
+	if submission != nil {
+		generateID(s.client, submission.Data)
+	}
+	path, err := joinPath(mandatesPath, mandateID, submissionsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodPost, path, submission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub := new(MandateSubmission)
+	resp, err := s.client.Do(ctx, request, sub)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sub, resp, nil
+}
+
+// FetchSubmission gets a single submission of a mandate.
+func (s *MandatesService) FetchSubmission(ctx context.Context, mandateID string, submissionID string) (*MandateSubmission, *http.Response, error) {
+	if err := validateIDs(mandateID, submissionID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(mandatesPath, mandateID, submissionsPath, submissionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub := new(MandateSubmission)
+	resp, err := s.client.Do(ctx, request, sub)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sub, resp, nil
+}
+
+// CreateReturn returns (cancels) a mandate.
+// If Client.GenerateIDs is set and the return has no ID, a random one is assigned to ret before sending.
+func (s *MandatesService) CreateReturn(ctx context.Context, mandateID string, ret *MandateReturn) (*MandateReturn, *http.Response, error) {
+	if err := validateIDs(mandateID); err != nil {
+		return nil, nil, err
+	}
+	if ret != nil {
+		generateID(s.client, ret.Data)
+	}
+	path, err := joinPath(mandatesPath, mandateID, returnsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodPost, path, ret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := new(MandateReturn)
+	resp, err := s.client.Do(ctx, request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// FetchReturn gets a single return of a mandate.
+func (s *MandatesService) FetchReturn(ctx context.Context, mandateID string, returnID string) (*MandateReturn, *http.Response, error) {
+	if err := validateIDs(mandateID, returnID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(mandatesPath, mandateID, returnsPath, returnID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := new(MandateReturn)
+	resp, err := s.client.Do(ctx, request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
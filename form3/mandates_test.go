@@ -0,0 +1,236 @@
+package form3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const (
+	testMandateID    = "7c2b4b5d-bc3a-4a6c-8d73-2c86b1a2d5e3"
+	testSubmissionID = "1d1f4fbe-7b36-4fd8-a0b5-3a9e2f7f2b51"
+)
+
+var expectedMandate = &Mandate{Data: &MandateData{
+	ResourceData: ResourceData{
+		Type:           "mandates",
+		ID:             testMandateID,
+		OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+	},
+	Attributes: &MandateAttributes{
+		Reference: "REF-0001",
+		Scheme:    SchemeBacs,
+		Currency:  "GBP",
+		DebtorParty: &Party{
+			AccountName:   "Samantha Holder",
+			AccountNumber: "41426819",
+			AccountWith:   &AccountWith{BankID: "400300", BankIDCode: "GBDSC"},
+		},
+		BeneficiaryParty: &Party{
+			AccountNumber: "71268996",
+			AccountWith:   &AccountWith{BankID: "400302", BankIDCode: "GBDSC"},
+		},
+	},
+}}
+
+func TestMandatesService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+mandatesPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		response, _ := json.Marshal(expectedMandate)
+		testBody(t, r, bytes.NewBuffer(response))
+		writeJSON(t, w, expectedMandate)
+	})
+
+	m, _, err := client.Mandates.Create(ctx, expectedMandate)
+	if err != nil {
+		t.Errorf("Mandates.Create returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(m, expectedMandate) {
+		t.Errorf("Mandates.Create returned %+v, expected %+v", m, expectedMandate)
+	}
+}
+
+func TestMandatesService_Fetch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+mandatesPath+"/"+testMandateID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		writeJSON(t, w, expectedMandate)
+	})
+
+	m, _, err := client.Mandates.Fetch(ctx, testMandateID)
+	if err != nil {
+		t.Errorf("Mandates.Fetch returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(m, expectedMandate) {
+		t.Errorf("Mandates.Fetch returned %+v, expected %+v", m, expectedMandate)
+	}
+}
+
+func TestMandatesService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+mandatesPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testQueryParam(t, r, "page[number]", "1")
+		testQueryParam(t, r, "page[size]", "10")
+		writeJSON(t, w, MandateList{Data: []*MandateData{expectedMandate.Data}})
+	})
+
+	list, _, err := client.Mandates.List(ctx, 1, 10)
+	if err != nil {
+		t.Errorf("Mandates.List returned error: %v", err)
+	}
+
+	if len(list.Data) != 1 || !reflect.DeepEqual(list.Data[0], expectedMandate.Data) {
+		t.Errorf("Mandates.List returned %+v, expected %+v", list, expectedMandate)
+	}
+}
+
+func TestMandatesService_Submission(t *testing.T) {
+	setup()
+	defer teardown()
+
+	submission := &MandateSubmission{Data: &MandateSubmissionData{
+		ResourceData: ResourceData{Type: "mandate_submissions", ID: testSubmissionID},
+		Attributes:   &SubmissionAttributes{Status: StatusAccepted},
+	}}
+	mux.HandleFunc("/v1/"+mandatesPath+"/"+testMandateID+"/submissions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		writeJSON(t, w, submission)
+	})
+	mux.HandleFunc("/v1/"+mandatesPath+"/"+testMandateID+"/submissions/"+testSubmissionID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		writeJSON(t, w, submission)
+	})
+
+	created, _, err := client.Mandates.CreateSubmission(ctx, testMandateID, submission)
+	if err != nil {
+		t.Errorf("Mandates.CreateSubmission returned error: %v", err)
+	}
+	if !reflect.DeepEqual(created, submission) {
+		t.Errorf("Mandates.CreateSubmission returned %+v, expected %+v", created, submission)
+	}
+
+	fetched, _, err := client.Mandates.FetchSubmission(ctx, testMandateID, testSubmissionID)
+	if err != nil {
+		t.Errorf("Mandates.FetchSubmission returned error: %v", err)
+	}
+	if !reflect.DeepEqual(fetched, submission) {
+		t.Errorf("Mandates.FetchSubmission returned %+v, expected %+v", fetched, submission)
+	}
+}
+
+func TestMandatesService_Return(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ret := &MandateReturn{Data: &MandateReturnData{
+		ResourceData: ResourceData{Type: "mandate_returns", ID: testSubmissionID},
+		Attributes:   &ReturnAttributes{ReturnCode: "1"},
+	}}
+	mux.HandleFunc("/v1/"+mandatesPath+"/"+testMandateID+"/returns", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		writeJSON(t, w, ret)
+	})
+	mux.HandleFunc("/v1/"+mandatesPath+"/"+testMandateID+"/returns/"+testSubmissionID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		writeJSON(t, w, ret)
+	})
+
+	created, _, err := client.Mandates.CreateReturn(ctx, testMandateID, ret)
+	if err != nil {
+		t.Errorf("Mandates.CreateReturn returned error: %v", err)
+	}
+	if !reflect.DeepEqual(created, ret) {
+		t.Errorf("Mandates.CreateReturn returned %+v, expected %+v", created, ret)
+	}
+
+	fetched, _, err := client.Mandates.FetchReturn(ctx, testMandateID, testSubmissionID)
+	if err != nil {
+		t.Errorf("Mandates.FetchReturn returned error: %v", err)
+	}
+	if !reflect.DeepEqual(fetched, ret) {
+		t.Errorf("Mandates.FetchReturn returned %+v, expected %+v", fetched, ret)
+	}
+}
+
+// echoGeneratedID answers a create request with its body, after checking that the resource has a valid ID.
+func echoGeneratedID(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		var doc struct {
+			Data *ResourceData `json:"data"`
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &doc); err != nil || doc.Data == nil {
+			t.Errorf("Create sent %s", body)
+			return
+		}
+		if err := doc.Data.ID.Validate(); err != nil {
+			t.Errorf("Create sent invalid ID: %v", err)
+		}
+		w.Write(body)
+	}
+}
+
+func TestMandatesService_CreateGeneratesIDs(t *testing.T) {
+	setup()
+	defer teardown()
+	client.GenerateIDs = true
+
+	mux.HandleFunc("/v1/"+mandatesPath, echoGeneratedID(t))
+	mux.HandleFunc("/v1/"+mandatesPath+"/"+testMandateID+"/submissions", echoGeneratedID(t))
+	mux.HandleFunc("/v1/"+mandatesPath+"/"+testMandateID+"/returns", echoGeneratedID(t))
+
+	mandate := &Mandate{Data: &MandateData{Attributes: &MandateAttributes{Reference: "REF-0001"}}}
+	if created, _, err := client.Mandates.Create(ctx, mandate); err != nil || created.Data.ID == "" || created.Data.ID != mandate.Data.ID {
+		t.Errorf("Mandates.Create returned %+v, %v", created, err)
+	}
+	submission := &MandateSubmission{Data: &MandateSubmissionData{}}
+	if created, _, err := client.Mandates.CreateSubmission(ctx, testMandateID, submission); err != nil || created.Data.ID == "" || created.Data.ID != submission.Data.ID {
+		t.Errorf("Mandates.CreateSubmission returned %+v, %v", created, err)
+	}
+	ret := &MandateReturn{Data: &MandateReturnData{Attributes: &ReturnAttributes{ReturnCode: "1"}}}
+	if created, _, err := client.Mandates.CreateReturn(ctx, testMandateID, ret); err != nil || created.Data.ID == "" || created.Data.ID != ret.Data.ID {
+		t.Errorf("Mandates.CreateReturn returned %+v, %v", created, err)
+	}
+}
+
+func TestMandatesService_invalidIDs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+	})
+
+	for _, id := range []string{"", "..", "../accounts"} {
+		if _, _, err := client.Mandates.Fetch(ctx, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Mandates.Fetch(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, _, err := client.Mandates.CreateSubmission(ctx, id, &MandateSubmission{}); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Mandates.CreateSubmission(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, _, err := client.Mandates.FetchSubmission(ctx, testMandateID, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Mandates.FetchSubmission(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, _, err := client.Mandates.CreateReturn(ctx, id, &MandateReturn{}); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Mandates.CreateReturn(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, _, err := client.Mandates.FetchReturn(ctx, testMandateID, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Mandates.FetchReturn(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package form3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+const (
+	// defaultSignatureHeader is the request header HMACSignatureVerifier reads by default.
+	defaultSignatureHeader = "Signature"
+	// defaultDeduplicationSize is the number of notification IDs remembered by default.
+	defaultDeduplicationSize = 10000
+	// maxNotificationSize limits the size of notification bodies that are read.
+	maxNotificationSize = 1 << 20
+)
+
+var (
+	// ErrInvalidSignature is returned by a SignatureVerifier when a request is not correctly signed.
+	ErrInvalidSignature = errors.New("invalid notification signature")
+	// ErrNoVerifier is passed to the ErrorHandler for requests rejected because no Verifier is configured.
+	ErrNoVerifier = errors.New("no notification signature verifier configured")
+	// ErrMalformedNotification is wrapped by the errors of notifications that cannot be decoded.
+	ErrMalformedNotification = errors.New("malformed notification")
+)
+
+// Notification represents a notification delivered by Form3 for a subscription.
+type Notification struct {
+	ID             UUID       `json:"id"`
+	OrganisationID UUID       `json:"organisation_id"`
+	EventType      EventType  `json:"event_type"`
+	RecordType     RecordType `json:"record_type"`
+	Version        int        `json:"version"`
+	// Data holds the resource the notification refers to.
+	Data json.RawMessage `json:"data"`
+}
+
+// AccountEvent is delivered when an account is created, updated or deleted.
+type AccountEvent struct {
+	*Notification
+	Account *AccountData
+}
+
+// MandateEvent is delivered when a mandate changes.
+type MandateEvent struct {
+	*Notification
+	Mandate *MandateData
+}
+
+// DirectDebitEvent is delivered when a direct debit changes.
+type DirectDebitEvent struct {
+	*Notification
+	DirectDebit *DirectDebitData
+}
+
+// SubmissionEvent is delivered when the status of a payment or mandate submission changes.
+type SubmissionEvent struct {
+	*Notification
+	Submission *SubmissionData
+}
+
+// SubmissionData represents the main attributes for a given payment or mandate submission.
+type SubmissionData struct {
+	ResourceData
+	Attributes *SubmissionAttributes `json:"attributes,omitempty"`
+}
+
+// SignatureVerifier verifies that a notification request was sent by Form3.
+type SignatureVerifier interface {
+	// Verify returns an error if the request with the given body is not correctly signed.
+	Verify(r *http.Request, body []byte) error
+}
+
+// SignatureVerifierFunc is an adapter to allow the use of ordinary functions as a SignatureVerifier.
+type SignatureVerifierFunc func(r *http.Request, body []byte) error
+
+// Verify calls f(r, body).
+func (f SignatureVerifierFunc) Verify(r *http.Request, body []byte) error {
+	return f(r, body)
+}
+
+// HMACSignatureVerifier verifies a base64 encoded HMAC-SHA256 of the request body
+// sent in a request header.
+type HMACSignatureVerifier struct {
+	// Secret is the shared key the signature is computed with.
+	Secret []byte
+	// Header is the request header holding the signature. Defaults to "Signature".
+	Header string
+}
+
+// Verify implements the SignatureVerifier interface.
+func (v *HMACSignatureVerifier) Verify(r *http.Request, body []byte) error {
+	header := v.Header
+	if header == "" {
+		header = defaultSignatureHeader
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(r.Header.Get(header))
+	if err != nil || len(signature) == 0 {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, v.Secret)
+	mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// NotificationHandlerOptions configures a NotificationHandler.
+type NotificationHandlerOptions struct {
+	// Verifier checks the signature of every request. Requests failing verification
+	// are answered with 401 Unauthorized. If Verifier is nil, all requests are rejected
+	// unless InsecureSkipVerify is set.
+	Verifier SignatureVerifier
+	// InsecureSkipVerify accepts requests without verifying their signature when Verifier is nil.
+	// It should only be set in tests or behind a proxy that verifies the signature.
+	InsecureSkipVerify bool
+	// DeduplicationSize is the number of handled notification IDs remembered
+	// to skip redeliveries. Defaults to 10000; a negative value disables deduplication.
+	DeduplicationSize int
+	// AckOnError acknowledges notifications whose callback failed with 200 OK.
+	// By default such notifications are answered with 500 so Form3 redelivers them.
+	// Notifications that cannot be decoded are always answered with 400, as a redelivery would fail again.
+	AckOnError bool
+	// ErrorHandler, if set, is called for every notification that could not be handled.
+	// n is nil if the request could not be decoded.
+	ErrorHandler func(r *http.Request, n *Notification, err error)
+}
+
+// NotificationHandler is an http.Handler receiving Form3 notifications.
+// It verifies and decodes the notifications, skips ones that were already handled
+// and dispatches them to the callbacks registered for their record type.
+// Notifications without a matching callback are acknowledged and dropped.
+type NotificationHandler struct {
+	opts *NotificationHandlerOptions
+
+	mu        sync.Mutex
+	callbacks map[RecordType]func(ctx context.Context, n *Notification) error
+	fallback  func(ctx context.Context, n *Notification) error
+	// seen holds the IDs of handled or in-flight notifications, order holds them oldest first.
+	seen  map[UUID]bool
+	order []UUID
+}
+
+// NewNotificationHandler returns a new NotificationHandler. If opts is nil, the defaults are used,
+// which reject every request as no Verifier is set.
+func NewNotificationHandler(opts *NotificationHandlerOptions) *NotificationHandler {
+	if opts == nil {
+		opts = &NotificationHandlerOptions{}
+	}
+	return &NotificationHandler{
+		opts:      opts,
+		callbacks: make(map[RecordType]func(ctx context.Context, n *Notification) error),
+		seen:      make(map[UUID]bool),
+	}
+}
+
+// OnAccount registers fn for account notifications.
+func (h *NotificationHandler) OnAccount(fn func(ctx context.Context, e *AccountEvent) error) {
+	h.handle(RecordTypeAccount, func(ctx context.Context, n *Notification) error {
+		e := &AccountEvent{Notification: n}
+		if err := n.decode(&e.Account); err != nil {
+			return err
+		}
+		return fn(ctx, e)
+	})
+}
+
+// OnMandate registers fn for mandate notifications.
+func (h *NotificationHandler) OnMandate(fn func(ctx context.Context, e *MandateEvent) error) {
+	h.handle(RecordTypeMandate, func(ctx context.Context, n *Notification) error {
+		e := &MandateEvent{Notification: n}
+		if err := n.decode(&e.Mandate); err != nil {
+			return err
+		}
+		return fn(ctx, e)
+	})
+}
+
+// OnDirectDebit registers fn for direct debit notifications.
+func (h *NotificationHandler) OnDirectDebit(fn func(ctx context.Context, e *DirectDebitEvent) error) {
+	h.handle(RecordTypeDirectDebit, func(ctx context.Context, n *Notification) error {
+		e := &DirectDebitEvent{Notification: n}
+		if err := n.decode(&e.DirectDebit); err != nil {
+			return err
+		}
+		return fn(ctx, e)
+	})
+}
+
+// OnSubmission registers fn for payment and mandate submission notifications,
+// which are sent when the status of a submission changes.
+func (h *NotificationHandler) OnSubmission(fn func(ctx context.Context, e *SubmissionEvent) error) {
+	callback := func(ctx context.Context, n *Notification) error {
+		e := &SubmissionEvent{Notification: n}
+		if err := n.decode(&e.Submission); err != nil {
+			return err
+		}
+		return fn(ctx, e)
+	}
+	h.handle(RecordTypePaymentSubmission, callback)
+	h.handle(RecordTypeMandateSubmission, callback)
+}
+
+// OnNotification registers fn for notifications of any record type without a more specific callback.
+func (h *NotificationHandler) OnNotification(fn func(ctx context.Context, n *Notification) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fallback = fn
+}
+
+func (h *NotificationHandler) handle(recordType RecordType, fn func(ctx context.Context, n *Notification) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.callbacks[recordType] = fn
+}
+
+// decode unmarshals the notification data into v.
+// The error returned wraps ErrMalformedNotification.
+func (n *Notification) decode(v interface{}) error {
+	if err := json.Unmarshal(n.Data, v); err != nil {
+		return fmt.Errorf("%w: decoding %s notification %s: %v", ErrMalformedNotification, n.RecordType, n.ID, err)
+	}
+	return nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *NotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxNotificationSize))
+	if err != nil {
+		h.fail(w, r, nil, err, http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case h.opts.Verifier != nil:
+		if err := h.opts.Verifier.Verify(r, body); err != nil {
+			h.fail(w, r, nil, err, http.StatusUnauthorized)
+			return
+		}
+	case !h.opts.InsecureSkipVerify:
+		h.fail(w, r, nil, ErrNoVerifier, http.StatusUnauthorized)
+		return
+	}
+
+	n := new(Notification)
+	if err := json.Unmarshal(body, n); err != nil {
+		h.fail(w, r, nil, fmt.Errorf("%w: %v", ErrMalformedNotification, err), http.StatusBadRequest)
+		return
+	}
+
+	if !h.reserve(n.ID) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	h.mu.Lock()
+	callback, ok := h.callbacks[n.RecordType]
+	if !ok {
+		callback = h.fallback
+	}
+	h.mu.Unlock()
+
+	if callback != nil {
+		if err := callback(r.Context(), n); err != nil {
+			h.release(n.ID)
+			status := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, ErrMalformedNotification):
+				status = http.StatusBadRequest
+			case h.opts.AckOnError:
+				status = http.StatusOK
+			}
+			h.fail(w, r, n, err, status)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *NotificationHandler) fail(w http.ResponseWriter, r *http.Request, n *Notification, err error, status int) {
+	if h.opts.ErrorHandler != nil {
+		h.opts.ErrorHandler(r, n, err)
+	}
+	w.WriteHeader(status)
+}
+
+// reserve marks id as handled and reports whether it was not seen before.
+func (h *NotificationHandler) reserve(id UUID) bool {
+	size := h.opts.DeduplicationSize
+	if size == 0 {
+		size = defaultDeduplicationSize
+	}
+	if size < 0 || id == "" {
+		return true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.seen[id] {
+		return false
+	}
+	h.seen[id] = true
+	h.order = append(h.order, id)
+	if len(h.order) > size {
+		delete(h.seen, h.order[0])
+		h.order = h.order[1:]
+	}
+	return true
+}
+
+// release forgets id, so a redelivery of a failed notification is handled again.
+func (h *NotificationHandler) release(id UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.seen[id] {
+		return
+	}
+	delete(h.seen, id)
+	for i := len(h.order) - 1; i >= 0; i-- {
+		if h.order[i] == id {
+			h.order = append(h.order[:i], h.order[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package form3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testNotificationID = "c3d5e7f9-1a2b-4c3d-8e4f-5a6b7c8d9e0f"
+
+func notificationBody(t *testing.T, id string, recordType RecordType, data interface{}) string {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("Unexpected error in test data: %v", err)
+	}
+	body, err := json.Marshal(&Notification{
+		ID:         UUID(id),
+		EventType:  EventTypeCreated,
+		RecordType: recordType,
+		Data:       raw,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error in test data: %v", err)
+	}
+	return string(body)
+}
+
+func deliver(h http.Handler, body string, header http.Header) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/notifications", strings.NewReader(body))
+	for k, v := range header {
+		r.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestNotificationHandler_dispatchesAccount(t *testing.T) {
+	h := NewNotificationHandler(&NotificationHandlerOptions{InsecureSkipVerify: true})
+	var got *AccountEvent
+	h.OnAccount(func(ctx context.Context, e *AccountEvent) error {
+		got = e
+		return nil
+	})
+
+	w := deliver(h, notificationBody(t, testNotificationID, RecordTypeAccount, expectedAccount.Data), nil)
+	if w.Code != http.StatusOK {
+		t.Errorf("Response code is %v, want %v", w.Code, http.StatusOK)
+	}
+	if got == nil {
+		t.Fatalf("OnAccount callback was not called")
+	}
+	if got.ID != testNotificationID || got.EventType != EventTypeCreated {
+		t.Errorf("Notification is %+v", got.Notification)
+	}
+	if !reflect.DeepEqual(got.Account, expectedAccount.Data) {
+		t.Errorf("Account is %+v, expected %+v", got.Account, expectedAccount.Data)
+	}
+}
+
+func TestNotificationHandler_dispatchesSubmission(t *testing.T) {
+	h := NewNotificationHandler(&NotificationHandlerOptions{InsecureSkipVerify: true})
+	var statuses []MandateStatus
+	h.OnSubmission(func(ctx context.Context, e *SubmissionEvent) error {
+		statuses = append(statuses, e.Submission.Attributes.Status)
+		return nil
+	})
+	h.OnNotification(func(ctx context.Context, n *Notification) error {
+		t.Errorf("Fallback should not be called for submissions")
+		return nil
+	})
+
+	data := &SubmissionData{Attributes: &SubmissionAttributes{Status: StatusAccepted}}
+	deliver(h, notificationBody(t, testNotificationID, RecordTypePaymentSubmission, data), nil)
+	deliver(h, notificationBody(t, testSubscriptionID, RecordTypeMandateSubmission, data), nil)
+
+	if want := []MandateStatus{StatusAccepted, StatusAccepted}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("Statuses are %v, want %v", statuses, want)
+	}
+}
+
+func TestNotificationHandler_fallbackAndUnhandled(t *testing.T) {
+	h := NewNotificationHandler(&NotificationHandlerOptions{InsecureSkipVerify: true})
+	w := deliver(h, notificationBody(t, testNotificationID, RecordTypePayment, struct{}{}), nil)
+	if w.Code != http.StatusOK {
+		t.Errorf("Unhandled notification response code is %v, want %v", w.Code, http.StatusOK)
+	}
+
+	var got RecordType
+	h.OnNotification(func(ctx context.Context, n *Notification) error {
+		got = n.RecordType
+		return nil
+	})
+	deliver(h, notificationBody(t, testSubscriptionID, RecordTypePayment, struct{}{}), nil)
+	if got != RecordTypePayment {
+		t.Errorf("Fallback received %q, want %q", got, RecordTypePayment)
+	}
+}
+
+func TestNotificationHandler_deduplicates(t *testing.T) {
+	h := NewNotificationHandler(&NotificationHandlerOptions{InsecureSkipVerify: true})
+	calls := 0
+	h.OnAccount(func(ctx context.Context, e *AccountEvent) error {
+		calls++
+		return nil
+	})
+
+	body := notificationBody(t, testNotificationID, RecordTypeAccount, expectedAccount.Data)
+	for i := 0; i < 3; i++ {
+		if w := deliver(h, body, nil); w.Code != http.StatusOK {
+			t.Errorf("Response code is %v, want %v", w.Code, http.StatusOK)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Callback called %d times, want 1", calls)
+	}
+}
+
+func TestNotificationHandler_errorSemantics(t *testing.T) {
+	fail := errors.New("boom")
+	for _, ackOnError := range []bool{false, true} {
+		var handled error
+		h := NewNotificationHandler(&NotificationHandlerOptions{
+			InsecureSkipVerify: true,
+			AckOnError:         ackOnError,
+			ErrorHandler:       func(r *http.Request, n *Notification, err error) { handled = err },
+		})
+		calls := 0
+		h.OnAccount(func(ctx context.Context, e *AccountEvent) error {
+			calls++
+			if calls == 1 {
+				return fail
+			}
+			return nil
+		})
+
+		body := notificationBody(t, testNotificationID, RecordTypeAccount, expectedAccount.Data)
+		want := http.StatusInternalServerError
+		if ackOnError {
+			want = http.StatusOK
+		}
+		if w := deliver(h, body, nil); w.Code != want {
+			t.Errorf("AckOnError=%v response code is %v, want %v", ackOnError, w.Code, want)
+		}
+		if handled != fail {
+			t.Errorf("ErrorHandler received %v, want %v", handled, fail)
+		}
+
+		// A failed notification is not remembered, so its redelivery is handled again.
+		deliver(h, body, nil)
+		if calls != 2 {
+			t.Errorf("Callback called %d times, want 2", calls)
+		}
+	}
+}
+
+func TestNotificationHandler_malformedBody(t *testing.T) {
+	h := NewNotificationHandler(&NotificationHandlerOptions{InsecureSkipVerify: true})
+	if w := deliver(h, "{not json", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Response code is %v, want %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestNotificationHandler_malformedData(t *testing.T) {
+	var handled error
+	h := NewNotificationHandler(&NotificationHandlerOptions{
+		InsecureSkipVerify: true,
+		AckOnError:         true,
+		ErrorHandler:       func(r *http.Request, n *Notification, err error) { handled = err },
+	})
+	h.OnAccount(func(ctx context.Context, e *AccountEvent) error {
+		t.Errorf("Callback should not be called for malformed data")
+		return nil
+	})
+
+	// A redelivery would fail to decode again, so it is rejected as a bad request even with AckOnError.
+	body := notificationBody(t, testNotificationID, RecordTypeAccount, []string{"not an account"})
+	if w := deliver(h, body, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Response code is %v, want %v", w.Code, http.StatusBadRequest)
+	}
+	if !errors.Is(handled, ErrMalformedNotification) {
+		t.Errorf("ErrorHandler received %v, want ErrMalformedNotification", handled)
+	}
+}
+
+func TestNotificationHandler_requiresVerifier(t *testing.T) {
+	var handled error
+	h := NewNotificationHandler(&NotificationHandlerOptions{
+		ErrorHandler: func(r *http.Request, n *Notification, err error) { handled = err },
+	})
+	h.OnAccount(func(ctx context.Context, e *AccountEvent) error {
+		t.Errorf("Callback should not be called without a verifier")
+		return nil
+	})
+
+	body := notificationBody(t, testNotificationID, RecordTypeAccount, expectedAccount.Data)
+	if w := deliver(h, body, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("Response code is %v, want %v", w.Code, http.StatusUnauthorized)
+	}
+	if !errors.Is(handled, ErrNoVerifier) {
+		t.Errorf("ErrorHandler received %v, want ErrNoVerifier", handled)
+	}
+	if w := deliver(NewNotificationHandler(nil), body, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("Default options response code is %v, want %v", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestNotificationHandler_verifiesSignature(t *testing.T) {
+	secret := []byte("s3cret")
+	h := NewNotificationHandler(&NotificationHandlerOptions{
+		Verifier: &HMACSignatureVerifier{Secret: secret},
+	})
+	calls := 0
+	h.OnAccount(func(ctx context.Context, e *AccountEvent) error {
+		calls++
+		return nil
+	})
+
+	body := notificationBody(t, testNotificationID, RecordTypeAccount, expectedAccount.Data)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(body))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if w := deliver(h, body, http.Header{"Signature": {"bm9wZQ=="}}); w.Code != http.StatusUnauthorized {
+		t.Errorf("Wrong signature response code is %v, want %v", w.Code, http.StatusUnauthorized)
+	}
+	if w := deliver(h, body, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("Missing signature response code is %v, want %v", w.Code, http.StatusUnauthorized)
+	}
+	if w := deliver(h, body, http.Header{"Signature": {signature}}); w.Code != http.StatusOK {
+		t.Errorf("Valid signature response code is %v, want %v", w.Code, http.StatusOK)
+	}
+	if calls != 1 {
+		t.Errorf("Callback called %d times, want 1", calls)
+	}
+}
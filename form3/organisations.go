@@ -0,0 +1,102 @@
+package form3
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// organisationsPath URL path to organisation resources.
+	organisationsPath = "organisation/units"
+)
+
+// Organisation represents an organisation registered with Form3.
+type Organisation struct {
+	Data *OrganisationData `json:"data"`
+}
+
+// OrganisationList represents a list of organisations.
+type OrganisationList struct {
+	Data []*OrganisationData `json:"data"`
+}
+
+// OrganisationData represents the main attributes for a given organisation.
+// For an organisation, OrganisationID holds the ID of its parent organisation.
+type OrganisationData struct {
+	ResourceData
+	Attributes *OrganisationAttributes `json:"attributes"`
+}
+
+// OrganisationAttributes represents the available organisation attribute fields.
+type OrganisationAttributes struct {
+	Name string `json:"name"`
+}
+
+// OrganisationsService handles the communication with the organisation related
+// methods of the Form3 API.
+//
+// Form3 API docs: https://api-docs.form3.tech/api.html?http#organisations
+type OrganisationsService service
+
+// Create creates a child organisation. The parent is given by the organisation's
+// OrganisationID; when using a client returned by Client.ForOrganisation it defaults
+// to the organisation the client is scoped to.
+func (s *OrganisationsService) Create(ctx context.Context, organisation *Organisation) (*Organisation, *http.Response, error) {
+	if organisation != nil {
+		generateID(s.client, organisation.Data)
+	}
+
+	request, err := s.client.NewRequest(http.MethodPost, organisationsPath, organisation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	org := new(Organisation)
+	resp, err := s.client.Do(ctx, request, org)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return org, resp, nil
+}
+
+// Fetch gets a single organisation using the organisation ID.
+func (s *OrganisationsService) Fetch(ctx context.Context, organisationID string) (*Organisation, *http.Response, error) {
+	if err := validateIDs(organisationID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(organisationsPath, organisationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	org := new(Organisation)
+	resp, err := s.client.Do(ctx, request, org)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return org, resp, nil
+}
+
+// List lists all organisations the caller has access to. Supports pagination.
+// Unlike other lists, it is not filtered when the client is scoped to an organisation.
+func (s *OrganisationsService) List(ctx context.Context, pageNumber int, pageSize int) (*OrganisationList, *http.Response, error) {
+	path := pagePath(organisationsPath, pageNumber, pageSize)
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(OrganisationList)
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, nil
+}
@@ -0,0 +1,127 @@
+package form3
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const (
+	testOrganisationID = "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"
+	testChildID        = "4a6c8e0a-2b4d-4f6a-8c0e-1a3c5e7a9c0b"
+)
+
+var expectedOrganisation = &Organisation{Data: &OrganisationData{
+	ResourceData: ResourceData{Type: "organisations", ID: testChildID, OrganisationID: testOrganisationID},
+	Attributes:   &OrganisationAttributes{Name: "Payments team"},
+}}
+
+func TestOrganisationsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+organisationsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		writeJSON(t, w, expectedOrganisation)
+	})
+
+	org, _, err := client.Organisations.Create(ctx, expectedOrganisation)
+	if err != nil {
+		t.Errorf("Organisations.Create returned error: %v", err)
+	}
+	if !reflect.DeepEqual(org, expectedOrganisation) {
+		t.Errorf("Organisations.Create returned %+v, expected %+v", org, expectedOrganisation)
+	}
+}
+
+func TestOrganisationsService_Fetch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+organisationsPath+"/"+testChildID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		writeJSON(t, w, expectedOrganisation)
+	})
+
+	org, _, err := client.Organisations.Fetch(ctx, testChildID)
+	if err != nil {
+		t.Errorf("Organisations.Fetch returned error: %v", err)
+	}
+	if !reflect.DeepEqual(org, expectedOrganisation) {
+		t.Errorf("Organisations.Fetch returned %+v, expected %+v", org, expectedOrganisation)
+	}
+}
+
+func TestOrganisationsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+organisationsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testQueryParam(t, r, "page[number]", "0")
+		testQueryParam(t, r, "filter[organisation_id]", "")
+		writeJSON(t, w, OrganisationList{Data: []*OrganisationData{expectedOrganisation.Data}})
+	})
+
+	list, _, err := client.ForOrganisation(testOrganisationID).Organisations.List(ctx, 0, 10)
+	if err != nil {
+		t.Errorf("Organisations.List returned error: %v", err)
+	}
+	if len(list.Data) != 1 || !reflect.DeepEqual(list.Data[0], expectedOrganisation.Data) {
+		t.Errorf("Organisations.List returned %+v, expected %+v", list, expectedOrganisation)
+	}
+}
+
+func TestClient_ForOrganisationStampsCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	mux.HandleFunc("/v1/"+accountsPath, func(w http.ResponseWriter, r *http.Request) {
+		sent = nil
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+		writeJSON(t, w, expectedAccount)
+	})
+
+	scoped := client.ForOrganisation(testChildID)
+	if scoped.OrganisationID() != testChildID || client.OrganisationID() != "" {
+		t.Errorf("ForOrganisation should only scope the returned client")
+	}
+
+	account := &Account{Data: &AccountData{ID: testAccountID, Attributes: &AccountAttributes{Country: "GB"}}}
+	if _, _, err := scoped.Accounts.Create(ctx, account); err != nil {
+		t.Fatalf("Accounts.Create returned error: %v", err)
+	}
+	if got := sent["data"]["organisation_id"]; got != testChildID {
+		t.Errorf("Sent organisation_id %v, want %v", got, testChildID)
+	}
+	if got := sent["data"]["id"]; got != testAccountID {
+		t.Errorf("Sent id %v, want %v", got, testAccountID)
+	}
+
+	account.Data.OrganisationID = testOrganisationID
+	if _, _, err := scoped.Accounts.Create(ctx, account); err != nil {
+		t.Fatalf("Accounts.Create returned error: %v", err)
+	}
+	if got := sent["data"]["organisation_id"]; got != testOrganisationID {
+		t.Errorf("Explicit organisation_id was overwritten with %v", got)
+	}
+}
+
+func TestClient_ForOrganisationScopesList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+accountsPath, func(w http.ResponseWriter, r *http.Request) {
+		testQueryParam(t, r, "filter[organisation_id]", testChildID)
+		testQueryParam(t, r, "page[size]", "10")
+		writeJSON(t, w, AccountList{Data: []*AccountData{}})
+	})
+
+	if _, _, err := client.ForOrganisation(testChildID).Accounts.List(ctx, 0, 10); err != nil {
+		t.Errorf("Accounts.List returned error: %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package form3
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// nameVerificationsPath URL path to Confirmation of Payee name verification resources.
+	nameVerificationsPath = "confirmation-of-payee/name-verifications"
+)
+
+// MatchResult is the outcome of a name verification.
+type MatchResult string
+
+// Possible outcomes of a name verification.
+const (
+	// MatchFull means the name and account type match the account.
+	MatchFull MatchResult = "full_match"
+	// MatchClose means the name is similar; the actual name is returned as a suggestion.
+	MatchClose MatchResult = "close_match"
+	// MatchNone means the name does not match the account.
+	MatchNone MatchResult = "no_match"
+	// MatchAccountTypeMismatch means the name matches, but the account is business instead of personal or vice versa.
+	MatchAccountTypeMismatch MatchResult = "account_type_mismatch"
+	// MatchUnavailable means the check could not be performed, e.g. the account does not exist or opted out.
+	MatchUnavailable MatchResult = "unavailable"
+)
+
+// ReasonCode is the Confirmation of Payee reason code returned when a name is not a full match.
+type ReasonCode string
+
+// Confirmation of Payee reason codes.
+const (
+	ReasonNameNotMatched            ReasonCode = "ANNM"
+	ReasonCloseMatch                ReasonCode = "MBAM"
+	ReasonBusinessNameMatched       ReasonCode = "BANM"
+	ReasonPersonalNameMatched       ReasonCode = "PANM"
+	ReasonBusinessCloseMatch        ReasonCode = "BAMM"
+	ReasonPersonalCloseMatch        ReasonCode = "PAMM"
+	ReasonAccountDoesNotExist       ReasonCode = "AC01"
+	ReasonInvalidSecondaryReference ReasonCode = "IVCR"
+	ReasonAccountNotSupported       ReasonCode = "ACNS"
+	ReasonOptedOut                  ReasonCode = "OPTO"
+	ReasonAccountSwitched           ReasonCode = "CASS"
+	ReasonSortCodeNotSupported      ReasonCode = "SCNS"
+)
+
+// Account types a name verification can be requested for.
+const (
+	AccountTypePersonal = "Personal"
+	AccountTypeBusiness = "Business"
+)
+
+// NameVerification represents a Confirmation of Payee name verification request and its result.
+type NameVerification struct {
+	Data *NameVerificationData `json:"data"`
+}
+
+// NameVerificationData represents the main attributes for a given name verification.
+type NameVerificationData struct {
+	ResourceData
+	Attributes *NameVerificationAttributes `json:"attributes"`
+}
+
+// NameVerificationAttributes represents the account and name to verify.
+type NameVerificationAttributes struct {
+	// BankID is the sort code of the account.
+	BankID        string `json:"bank_id"`
+	BankIDCode    string `json:"bank_id_code,omitempty"`
+	AccountNumber string `json:"account_number"`
+	// Name is the name the payer expects the account to be held by.
+	Name string `json:"name"`
+	// AccountClassification is either AccountTypePersonal or AccountTypeBusiness.
+	AccountClassification   string                  `json:"account_classification,omitempty"`
+	SecondaryIdentification string                  `json:"secondary_identification,omitempty"`
+	Result                  *NameVerificationResult `json:"result,omitempty"`
+}
+
+// NameVerificationResult represents the answer of the account servicing bank.
+type NameVerificationResult struct {
+	Matched    bool       `json:"matched"`
+	ReasonCode ReasonCode `json:"reason_code,omitempty"`
+	// ActualName holds the name on the account for close and account type matches.
+	ActualName string `json:"actual_name,omitempty"`
+}
+
+// Match classifies the result by its reason code.
+func (r *NameVerificationResult) Match() MatchResult {
+	if r.Matched && r.ReasonCode == "" {
+		return MatchFull
+	}
+	switch r.ReasonCode {
+	case "":
+		return MatchNone
+	case ReasonCloseMatch, ReasonBusinessCloseMatch, ReasonPersonalCloseMatch:
+		return MatchClose
+	case ReasonNameNotMatched:
+		return MatchNone
+	case ReasonBusinessNameMatched, ReasonPersonalNameMatched:
+		return MatchAccountTypeMismatch
+	default:
+		return MatchUnavailable
+	}
+}
+
+// AccountIdentificationService handles the communication with the
+// Confirmation of Payee methods of the Form3 API.
+//
+// Form3 API docs: https://api-docs.form3.tech/api.html?http#confirmation-of-payee
+type AccountIdentificationService service
+
+// VerifyName asks the bank servicing the given sort code and account number
+// whether the account is held by the given name.
+// If Client.GenerateIDs is set and the request has no ID, a random one is assigned to verification before sending.
+func (s *AccountIdentificationService) VerifyName(ctx context.Context, verification *NameVerification) (*NameVerification, *http.Response, error) {
+	if verification != nil {
+		generateID(s.client, verification.Data)
+	}
+
+	request, err := s.client.NewRequest(http.MethodPost, nameVerificationsPath, verification)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := new(NameVerification)
+	resp, err := s.client.Do(ctx, request, v)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return v, resp, nil
+}
+
+// FetchNameVerification gets a single name verification with its result.
+func (s *AccountIdentificationService) FetchNameVerification(ctx context.Context, verificationID string) (*NameVerification, *http.Response, error) {
+	if err := validateIDs(verificationID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(nameVerificationsPath, verificationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := new(NameVerification)
+	resp, err := s.client.Do(ctx, request, v)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return v, resp, nil
+}
@@ -0,0 +1,100 @@
+package form3
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testVerificationID = "2b4d6f8a-0c1e-4a3b-9d5f-7a9c1e3b5d7f"
+
+func TestAccountIdentificationService_VerifyName(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+nameVerificationsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		v := new(NameVerification)
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+		v.Data.Attributes.Result = &NameVerificationResult{ReasonCode: ReasonCloseMatch, ActualName: "Samantha Holder"}
+		writeJSON(t, w, v)
+	})
+
+	request := &NameVerification{Data: &NameVerificationData{
+		ResourceData: ResourceData{Type: "name_verifications", ID: testVerificationID},
+		Attributes: &NameVerificationAttributes{
+			BankID:                "400300",
+			BankIDCode:            "GBDSC",
+			AccountNumber:         "41426819",
+			Name:                  "Sam Holder",
+			AccountClassification: AccountTypePersonal,
+		},
+	}}
+	v, _, err := client.AccountIdentification.VerifyName(ctx, request)
+	if err != nil {
+		t.Fatalf("AccountIdentification.VerifyName returned error: %v", err)
+	}
+
+	result := v.Data.Attributes.Result
+	if result.Match() != MatchClose || result.ActualName != "Samantha Holder" {
+		t.Errorf("AccountIdentification.VerifyName returned %+v", result)
+	}
+}
+
+func TestAccountIdentificationService_FetchNameVerification(t *testing.T) {
+	setup()
+	defer teardown()
+
+	expected := &NameVerification{Data: &NameVerificationData{
+		ResourceData: ResourceData{Type: "name_verifications", ID: testVerificationID},
+		Attributes: &NameVerificationAttributes{
+			BankID:        "400300",
+			AccountNumber: "41426819",
+			Name:          "Samantha Holder",
+			Result:        &NameVerificationResult{Matched: true},
+		},
+	}}
+	mux.HandleFunc("/v1/"+nameVerificationsPath+"/"+testVerificationID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		writeJSON(t, w, expected)
+	})
+
+	v, _, err := client.AccountIdentification.FetchNameVerification(ctx, testVerificationID)
+	if err != nil {
+		t.Errorf("AccountIdentification.FetchNameVerification returned error: %v", err)
+	}
+	if !reflect.DeepEqual(v, expected) {
+		t.Errorf("AccountIdentification.FetchNameVerification returned %+v, expected %+v", v, expected)
+	}
+
+	if _, _, err := client.AccountIdentification.FetchNameVerification(ctx, "../accounts"); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("AccountIdentification.FetchNameVerification returned %v, want ErrInvalidUUID", err)
+	}
+}
+
+func TestNameVerificationResult_Match(t *testing.T) {
+	tests := []struct {
+		result NameVerificationResult
+		want   MatchResult
+	}{
+		{result: NameVerificationResult{Matched: true}, want: MatchFull},
+		{result: NameVerificationResult{}, want: MatchNone},
+		{result: NameVerificationResult{ReasonCode: ReasonNameNotMatched}, want: MatchNone},
+		{result: NameVerificationResult{ReasonCode: ReasonCloseMatch}, want: MatchClose},
+		{result: NameVerificationResult{ReasonCode: ReasonPersonalCloseMatch}, want: MatchClose},
+		{result: NameVerificationResult{ReasonCode: ReasonBusinessNameMatched}, want: MatchAccountTypeMismatch},
+		{result: NameVerificationResult{ReasonCode: ReasonPersonalNameMatched}, want: MatchAccountTypeMismatch},
+		{result: NameVerificationResult{ReasonCode: ReasonAccountDoesNotExist}, want: MatchUnavailable},
+		{result: NameVerificationResult{ReasonCode: ReasonOptedOut}, want: MatchUnavailable},
+	}
+
+	for _, tt := range tests {
+		if got := tt.result.Match(); got != tt.want {
+			t.Errorf("Match(%+v) is %v, want %v", tt.result, got, tt.want)
+		}
+	}
+}
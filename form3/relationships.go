@@ -0,0 +1,130 @@
+package form3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrRelationshipNotFound is returned when a resource has no related resource under the given name.
+	ErrRelationshipNotFound = errors.New("relationship not found")
+	// ErrRelationshipType is returned when a related resource is not of the expected type.
+	ErrRelationshipType = errors.New("related resource has an unexpected type")
+)
+
+// ResourceIdentifier identifies a related resource.
+type ResourceIdentifier struct {
+	Type string `json:"type"`
+	ID   UUID   `json:"id"`
+}
+
+// Relationship represents a JSON:API relationship of a resource, e.g. an account
+// to its master account. Data holds a single ResourceIdentifier for to-one
+// relationships and an array of them for to-many relationships.
+type Relationship struct {
+	Data  json.RawMessage `json:"data,omitempty"`
+	Links json.RawMessage `json:"links,omitempty"`
+	Meta  json.RawMessage `json:"meta,omitempty"`
+}
+
+// Identifiers returns the identifiers of the related resources.
+// An empty relationship returns no identifiers.
+func (r *Relationship) Identifiers() ([]ResourceIdentifier, error) {
+	data := bytes.TrimSpace(r.Data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	if data[0] == '[' {
+		var ids []ResourceIdentifier
+		if err := json.Unmarshal(data, &ids); err != nil {
+			return nil, err
+		}
+		return ids, nil
+	}
+	var id ResourceIdentifier
+	if err := json.Unmarshal(data, &id); err != nil {
+		return nil, err
+	}
+	return []ResourceIdentifier{id}, nil
+}
+
+// Relationships maps relationship names to relationships.
+type Relationships map[string]*Relationship
+
+// Identifiers returns the identifiers of the resources related under name.
+// An error wrapping ErrRelationshipNotFound is returned if there are none.
+func (r Relationships) Identifiers(name string) ([]ResourceIdentifier, error) {
+	rel, ok := r[name]
+	if !ok || rel == nil {
+		return nil, fmt.Errorf("%w: %q", ErrRelationshipNotFound, name)
+	}
+	ids, err := rel.Identifiers()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrRelationshipNotFound, name)
+	}
+	return ids, nil
+}
+
+// Fetcher fetches single resources of type T by ID. ResourceService implements it.
+type Fetcher[T any] interface {
+	Fetch(ctx context.Context, id string) (*T, *http.Response, error)
+}
+
+// Resolve returns the resources related under name, which must all be of resourceType,
+// e.g. "accounts". Each resource is decoded from included, the included array of the
+// document holding the relationship, when it is present there and fetched with fetcher otherwise.
+// An error wrapping ErrRelationshipType is returned, before anything is fetched, if a related
+// resource is of another type.
+func Resolve[T any](ctx context.Context, fetcher Fetcher[T], resourceType string, relationships Relationships, name string, included []json.RawMessage) ([]*T, error) {
+	ids, err := relationships.Identifiers(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if id.Type != resourceType {
+			return nil, fmt.Errorf("%w: %q is %q, want %q", ErrRelationshipType, name, id.Type, resourceType)
+		}
+	}
+
+	resources := make([]*T, 0, len(ids))
+	for _, id := range ids {
+		resource, err := findIncluded[T](included, id)
+		if err != nil {
+			return nil, err
+		}
+		if resource == nil {
+			if resource, _, err = fetcher.Fetch(ctx, id.ID.String()); err != nil {
+				return nil, err
+			}
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// findIncluded decodes the resource identified by id from included.
+// It returns nil if included does not hold the resource.
+func findIncluded[T any](included []json.RawMessage, id ResourceIdentifier) (*T, error) {
+	for _, raw := range included {
+		var candidate ResourceIdentifier
+		if err := json.Unmarshal(raw, &candidate); err != nil {
+			return nil, err
+		}
+		if candidate != id {
+			continue
+		}
+		resource := new(T)
+		if err := json.Unmarshal(raw, resource); err != nil {
+			return nil, err
+		}
+		return resource, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,86 @@
+package form3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+const testMasterAccountID = "5f6e7d8c-9b0a-4c1d-8e2f-3a4b5c6d7e8f"
+
+func TestRelationship_Identifiers(t *testing.T) {
+	tests := []struct {
+		data string
+		want int
+	}{
+		{data: ``, want: 0},
+		{data: `null`, want: 0},
+		{data: `{"type":"accounts","id":"` + testAccountID + `"}`, want: 1},
+		{data: `[{"type":"accounts","id":"` + testAccountID + `"},{"type":"accounts","id":"` + testMasterAccountID + `"}]`, want: 2},
+	}
+
+	for _, tt := range tests {
+		ids, err := (&Relationship{Data: json.RawMessage(tt.data)}).Identifiers()
+		if err != nil {
+			t.Errorf("Identifiers(%s) returned error: %v", tt.data, err)
+		}
+		if len(ids) != tt.want {
+			t.Errorf("Identifiers(%s) returned %v, want %d identifiers", tt.data, ids, tt.want)
+		}
+	}
+}
+
+func TestAccountsService_Related(t *testing.T) {
+	setup()
+	defer teardown()
+
+	relationships := `"relationships":{"master_account":{"data":[` +
+		`{"type":"accounts","id":"` + testMasterAccountID + `"},{"type":"accounts","id":"` + testAccountID + `"}]}}`
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testChildID, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"type":"accounts","id":"`+testChildID+`",`+relationships+`},`+
+			`"included":[{"type":"accounts","id":"`+testMasterAccountID+`","attributes":{"country":"GB","name":["Master"],"status":"confirmed"}}]}`)
+	})
+	fetched := 0
+	mux.HandleFunc("/v1/"+accountsPath+"/"+testAccountID, func(w http.ResponseWriter, r *http.Request) {
+		fetched++
+		writeJSON(t, w, expectedAccount)
+	})
+
+	account, _, err := client.Accounts.Fetch(ctx, testChildID)
+	if err != nil {
+		t.Fatalf("Accounts.Fetch returned error: %v", err)
+	}
+	related, err := client.Accounts.Related(ctx, account, "master_account")
+	if err != nil {
+		t.Fatalf("Accounts.Related returned error: %v", err)
+	}
+	if len(related) != 2 || related[0].Data.Attributes.Name[0] != "Master" || related[1].Data.ID != testAccountID {
+		t.Errorf("Accounts.Related returned %+v", related)
+	}
+	if fetched != 1 {
+		t.Errorf("Accounts.Related fetched %d accounts, want only the one not included", fetched)
+	}
+
+	if _, err := client.Accounts.Related(ctx, account, "owner"); !errors.Is(err, ErrRelationshipNotFound) {
+		t.Errorf("Accounts.Related returned %v, want ErrRelationshipNotFound", err)
+	}
+}
+
+func TestAccountsService_RelatedOtherType(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+accountsPath+"/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Related resource of another type should not be fetched, got %s", r.URL.Path)
+	})
+
+	account := &Account{Data: &AccountData{ID: testChildID, Relationships: Relationships{
+		"submissions": {Data: json.RawMessage(`[{"type":"accounts","id":"` + testAccountID + `"},` +
+			`{"type":"payment_submissions","id":"` + testSubmissionID + `"}]`)},
+	}}}
+	if _, err := client.Accounts.Related(ctx, account, "submissions"); !errors.Is(err, ErrRelationshipType) {
+		t.Errorf("Accounts.Related returned %v, want ErrRelationshipType", err)
+	}
+}
@@ -0,0 +1,195 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// reportsPath URL path to report resources.
+	reportsPath = "reports"
+	// reportFilePath URL path segment of the generated report file.
+	reportFilePath = "file"
+)
+
+// ReportStatus is the generation status of a report.
+type ReportStatus string
+
+// Statuses of a report while it is generated.
+const (
+	ReportStatusPending    ReportStatus = "pending"
+	ReportStatusInProgress ReportStatus = "in_progress"
+	ReportStatusCompleted  ReportStatus = "completed"
+	ReportStatusFailed     ReportStatus = "failed"
+)
+
+// ReportType is the kind of a report.
+type ReportType string
+
+// Types of reports that can be requested.
+const (
+	ReportTypeStatement    ReportType = "statement"
+	ReportTypeTransactions ReportType = "transactions"
+	ReportTypeScheme       ReportType = "scheme"
+)
+
+// ErrReportFailed is returned by WaitForCompletion when the report could not be generated.
+var ErrReportFailed = errors.New("report generation failed")
+
+// Report represents a requested report and its generation status.
+type Report struct {
+	Data *ReportData `json:"data"`
+}
+
+// ReportData represents the main attributes for a given report.
+type ReportData struct {
+	ResourceData
+	Attributes *ReportAttributes `json:"attributes"`
+}
+
+// ReportAttributes represents the available report attribute fields.
+type ReportAttributes struct {
+	ReportType ReportType `json:"report_type"`
+	// FromDate and ToDate limit the report to a date range, in the format 2006-01-02.
+	FromDate string `json:"from_date,omitempty"`
+	ToDate   string `json:"to_date,omitempty"`
+	// AccountIDs limits the report to the given accounts.
+	AccountIDs   []UUID       `json:"account_ids,omitempty"`
+	Format       string       `json:"format,omitempty"`
+	Status       ReportStatus `json:"status,omitempty"`
+	StatusReason string       `json:"status_reason,omitempty"`
+}
+
+// PollOptions configures how often WaitForCompletion checks the report status.
+// The interval starts at InitialInterval and is multiplied by Multiplier after
+// every check, up to MaxInterval.
+type PollOptions struct {
+	// InitialInterval defaults to 1 second.
+	InitialInterval time.Duration
+	// MaxInterval defaults to 30 seconds.
+	MaxInterval time.Duration
+	// Multiplier defaults to 2.
+	Multiplier float64
+}
+
+// ReportsService handles the communication with the report related
+// methods of the Form3 API.
+//
+// Form3 API docs: https://api-docs.form3.tech/api.html?http#reports
+type ReportsService service
+
+// Create requests a new report. The report is generated asynchronously;
+// use WaitForCompletion to wait for it and Download to retrieve it.
+// If Client.GenerateIDs is set and the report has no ID, a random one is assigned to report before sending.
+func (s *ReportsService) Create(ctx context.Context, report *Report) (*Report, *http.Response, error) {
+	if report != nil {
+		generateID(s.client, report.Data)
+	}
+
+	request, err := s.client.NewRequest(http.MethodPost, reportsPath, report)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := new(Report)
+	resp, err := s.client.Do(ctx, request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// Fetch gets a single report, including its generation status, using the report ID.
+func (s *ReportsService) Fetch(ctx context.Context, reportID string) (*Report, *http.Response, error) {
+	if err := validateIDs(reportID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(reportsPath, reportID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := new(Report)
+	resp, err := s.client.Do(ctx, request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// WaitForCompletion polls the report status with backoff until it is completed.
+// If the report failed, it is returned together with an error wrapping ErrReportFailed.
+// Polling stops when ctx is canceled. If opts is nil, the defaults are used.
+func (s *ReportsService) WaitForCompletion(ctx context.Context, reportID string, opts *PollOptions) (*Report, error) {
+	interval, maxInterval, multiplier := time.Second, 30*time.Second, 2.0
+	if opts != nil {
+		if opts.InitialInterval > 0 {
+			interval = opts.InitialInterval
+		}
+		if opts.MaxInterval > 0 {
+			maxInterval = opts.MaxInterval
+		}
+		if opts.Multiplier >= 1 {
+			multiplier = opts.Multiplier
+		}
+	}
+
+	for {
+		report, _, err := s.Fetch(ctx, reportID)
+		if err != nil {
+			return nil, err
+		}
+		if report.Data == nil {
+			return nil, fmt.Errorf("fetching report %s: response has no data", reportID)
+		}
+		if a := report.Data.Attributes; a != nil {
+			switch a.Status {
+			case ReportStatusCompleted:
+				return report, nil
+			case ReportStatusFailed:
+				return report, fmt.Errorf("%w: report %s: %s", ErrReportFailed, reportID, a.StatusReason)
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * multiplier)
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// Download streams the generated report file to w without buffering it in memory.
+func (s *ReportsService) Download(ctx context.Context, reportID string, w io.Writer) (*http.Response, error) {
+	if err := validateIDs(reportID); err != nil {
+		return nil, err
+	}
+	path, err := joinPath(reportsPath, reportID, reportFilePath)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "*/*")
+
+	return s.client.Do(ctx, request, w)
+}
@@ -0,0 +1,154 @@
+package form3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testReportID = "e8a0c2e4-a6c8-4e0a-8c4e-8a0c2e4a6c8e"
+
+var fastPoll = &PollOptions{InitialInterval: time.Millisecond, MaxInterval: 2 * time.Millisecond}
+
+func TestReportsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	report := &Report{Data: &ReportData{
+		ResourceData: ResourceData{Type: "reports", ID: testReportID},
+		Attributes: &ReportAttributes{
+			ReportType: ReportTypeStatement,
+			FromDate:   "2020-01-01",
+			ToDate:     "2020-01-31",
+			AccountIDs: []UUID{testAccountID},
+		},
+	}}
+	mux.HandleFunc("/v1/"+reportsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		writeJSON(t, w, report)
+	})
+
+	created, _, err := client.Reports.Create(ctx, report)
+	if err != nil {
+		t.Fatalf("Reports.Create returned error: %v", err)
+	}
+	if created.Data.Attributes.ReportType != ReportTypeStatement {
+		t.Errorf("Reports.Create returned %+v", created.Data.Attributes)
+	}
+}
+
+func reportWithStatus(status ReportStatus) *Report {
+	return &Report{Data: &ReportData{
+		ResourceData: ResourceData{ID: testReportID},
+		Attributes:   &ReportAttributes{Status: status, StatusReason: "no data"},
+	}}
+}
+
+func TestReportsService_WaitForCompletion(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := 0
+	mux.HandleFunc("/v1/"+reportsPath+"/"+testReportID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		polls++
+		status := ReportStatusInProgress
+		if polls == 3 {
+			status = ReportStatusCompleted
+		}
+		writeJSON(t, w, reportWithStatus(status))
+	})
+
+	report, err := client.Reports.WaitForCompletion(ctx, testReportID, fastPoll)
+	if err != nil {
+		t.Fatalf("Reports.WaitForCompletion returned error: %v", err)
+	}
+	if report.Data.Attributes.Status != ReportStatusCompleted || polls != 3 {
+		t.Errorf("Reports.WaitForCompletion returned %+v after %d polls", report.Data.Attributes, polls)
+	}
+}
+
+func TestReportsService_WaitForCompletionFailed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+reportsPath+"/"+testReportID, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, reportWithStatus(ReportStatusFailed))
+	})
+
+	report, err := client.Reports.WaitForCompletion(ctx, testReportID, fastPoll)
+	if !errors.Is(err, ErrReportFailed) {
+		t.Errorf("Reports.WaitForCompletion returned %v, want ErrReportFailed", err)
+	}
+	if report == nil || report.Data.Attributes.StatusReason != "no data" {
+		t.Errorf("Reports.WaitForCompletion should return the failed report")
+	}
+}
+
+func TestReportsService_WaitForCompletionCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+reportsPath+"/"+testReportID, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, reportWithStatus(ReportStatusPending))
+	})
+
+	c, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Reports.WaitForCompletion(c, testReportID, fastPoll); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Reports.WaitForCompletion returned %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestReportsService_WaitForCompletionWithoutData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+reportsPath+"/"+testReportID, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":null}`)
+	})
+
+	if report, err := client.Reports.WaitForCompletion(ctx, testReportID, fastPoll); err == nil || report != nil {
+		t.Errorf("Reports.WaitForCompletion returned %v, %v, want an error", report, err)
+	}
+}
+
+func TestReportsService_WaitForCompletionEmptyResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+reportsPath+"/"+testReportID, func(w http.ResponseWriter, r *http.Request) {})
+
+	if _, err := client.Reports.WaitForCompletion(ctx, testReportID, fastPoll); err == nil || !strings.Contains(err.Error(), "no data") {
+		t.Errorf("Reports.WaitForCompletion returned %v, want an error for the missing data", err)
+	}
+}
+
+func TestReportsService_Download(t *testing.T) {
+	setup()
+	defer teardown()
+
+	content := strings.Repeat("date,amount\n2020-01-01,10.00\n", 1000)
+	mux.HandleFunc("/v1/"+reportsPath+"/"+testReportID+"/file", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(content))
+	})
+
+	var buf bytes.Buffer
+	if _, err := client.Reports.Download(ctx, testReportID, &buf); err != nil {
+		t.Fatalf("Reports.Download returned error: %v", err)
+	}
+	if buf.String() != content {
+		t.Errorf("Reports.Download wrote %d bytes, want %d", buf.Len(), len(content))
+	}
+
+	if _, err := client.Reports.Download(ctx, "../accounts", &buf); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("Reports.Download returned %v, want ErrInvalidUUID", err)
+	}
+}
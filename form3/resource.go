@@ -0,0 +1,270 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// Resource is implemented by the data of resources that carry an ID.
+// Every type embedding ResourceData implements it.
+type Resource interface {
+	ResourceID() UUID
+	SetResourceID(id UUID)
+}
+
+// ResourceID returns the ID of the resource.
+func (d *ResourceData) ResourceID() UUID {
+	return d.ID
+}
+
+// SetResourceID sets the ID of the resource.
+func (d *ResourceData) SetResourceID(id UUID) {
+	d.ID = id
+}
+
+// Document represents a JSON:API document holding a single resource.
+type Document[T any] struct {
+	Data *T `json:"data"`
+	// Included holds related resources sent along with Data, see Resolve.
+	Included []json.RawMessage `json:"included,omitempty"`
+}
+
+// ListDocument represents a JSON:API document holding a list of resources.
+type ListDocument[T any] struct {
+	Data []*T `json:"data"`
+}
+
+// ListOptions configures the page and filters of a list request.
+type ListOptions struct {
+	PageNumber int
+	PageSize   int
+	// Filter is sent as filter[key]=value query parameters.
+	Filter map[string]string
+}
+
+// ResourceService provides the create, fetch, list, update and delete methods
+// shared by the resources of the Form3 API. T is the type of the resource data,
+// e.g. AccountData, and path the URL path to the resources, e.g. "organisation/accounts".
+type ResourceService[T any] struct {
+	client *Client
+	path   string
+}
+
+// NewResourceService returns a service for the resources of type T at path.
+func NewResourceService[T any](client *Client, path string) *ResourceService[T] {
+	return &ResourceService[T]{client: client, path: path}
+}
+
+// generateID assigns a random ID to data if Client.GenerateIDs is set and data implements Resource without an ID.
+// Every Create method calls it before sending.
+func generateID[T any](c *Client, data *T) {
+	if r, ok := any(data).(Resource); ok && data != nil && c.GenerateIDs && r.ResourceID() == "" {
+		r.SetResourceID(NewUUID())
+	}
+}
+
+// Create creates a new resource.
+// If Client.GenerateIDs is set and data implements Resource without an ID, a random one is assigned to data before sending.
+func (s *ResourceService[T]) Create(ctx context.Context, data *T) (*T, *http.Response, error) {
+	generateID(s.client, data)
+
+	request, err := s.client.NewRequest(http.MethodPost, s.path, &Document[T]{Data: data})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc := new(Document[T])
+	resp, err := s.client.Do(ctx, request, doc)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return doc.Data, resp, nil
+}
+
+// Fetch gets a single resource using its ID.
+// An error wrapping ErrInvalidUUID is returned if id is not a valid UUID.
+func (s *ResourceService[T]) Fetch(ctx context.Context, id string) (*T, *http.Response, error) {
+	doc, resp, err := s.FetchDocument(ctx, id)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return doc.Data, resp, nil
+}
+
+// FetchDocument gets a single resource using its ID, together with the included related resources.
+// An error wrapping ErrInvalidUUID is returned if id is not a valid UUID.
+func (s *ResourceService[T]) FetchDocument(ctx context.Context, id string) (*Document[T], *http.Response, error) {
+	if err := validateIDs(id); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(s.path, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc := new(Document[T])
+	resp, err := s.client.Do(ctx, request, doc)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return doc, resp, nil
+}
+
+// List lists a page of resources. If opts is nil, the first page of the API's default size is requested.
+func (s *ResourceService[T]) List(ctx context.Context, opts *ListOptions) ([]*T, *http.Response, error) {
+	if opts == nil {
+		opts = new(ListOptions)
+	}
+	path := s.client.listPath(s.path, opts.PageNumber, opts.PageSize)
+	keys := make([]string, 0, len(opts.Filter))
+	for key := range opts.Filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		path += fmt.Sprintf("&filter[%s]=%s", url.QueryEscape(key), url.QueryEscape(opts.Filter[key]))
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(ListDocument[T])
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list.Data, resp, nil
+}
+
+// Update patches a resource. The ID of the resource is read from data, which must implement Resource.
+func (s *ResourceService[T]) Update(ctx context.Context, data *T) (*T, *http.Response, error) {
+	r, ok := any(data).(Resource)
+	if !ok || data == nil {
+		return nil, nil, errors.New("resource data should not be nil and must implement Resource")
+	}
+	id := r.ResourceID().String()
+	if err := validateIDs(id); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(s.path, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodPatch, path, &Document[T]{Data: data})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc := new(Document[T])
+	resp, err := s.client.Do(ctx, request, doc)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return doc.Data, resp, nil
+}
+
+// Delete deletes a resource by ID and given version.
+// An error wrapping ErrInvalidUUID is returned if id is not a valid UUID.
+func (s *ResourceService[T]) Delete(ctx context.Context, id string, version int) (*http.Response, error) {
+	if err := validateIDs(id); err != nil {
+		return nil, err
+	}
+	path, err := joinPath(s.path, id)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("%s?version=%d", path, version), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, request, nil)
+}
+
+// Iterate returns an iterator over all resources matching opts, starting at opts.PageNumber.
+// If opts is nil or its page size is not positive, pages of 100 resources are requested.
+func (s *ResourceService[T]) Iterate(opts *ListOptions) *Iterator[T] {
+	return newIterator(s.List, opts)
+}
+
+// newIterator returns an iterator over the pages returned by list, starting at opts.PageNumber.
+// If opts is nil or its page size is not positive, pages of 100 resources are requested.
+func newIterator[T any](list func(ctx context.Context, opts *ListOptions) ([]*T, *http.Response, error), opts *ListOptions) *Iterator[T] {
+	it := &Iterator[T]{list: list}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.PageSize <= 0 {
+		it.opts.PageSize = defaultExportPageSize
+	}
+	return it
+}
+
+// Iterator pages through a list of resources, e.g. of a ResourceService. Use it as:
+//
+//	it := service.Iterate(nil)
+//	for it.Next(ctx) {
+//		item := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	list func(ctx context.Context, opts *ListOptions) ([]*T, *http.Response, error)
+	opts ListOptions
+
+	buf     []*T
+	current *T
+	done    bool
+	err     error
+}
+
+// Next advances to the next resource, fetching the next page when needed.
+// It returns false when there are no more resources or an error occurred.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if it.done {
+			it.current = nil
+			return false
+		}
+		list, _, err := it.list(ctx, &it.opts)
+		if err != nil {
+			it.err = err
+			it.current = nil
+			return false
+		}
+		it.opts.PageNumber++
+		it.buf = list
+		it.done = len(list) < it.opts.PageSize
+	}
+
+	it.current, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current resource.
+func (it *Iterator[T]) Item() *T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
@@ -0,0 +1,124 @@
+package form3
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestResourceService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+	client.GenerateIDs = true
+
+	mux.HandleFunc("/v1/"+rolesPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		var doc Document[RoleData]
+		if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if err := doc.Data.ID.Validate(); err != nil {
+			t.Errorf("Request ID %q is not a generated UUID", doc.Data.ID)
+		}
+		writeJSON(t, w, doc)
+	})
+
+	roles := NewResourceService[RoleData](client, rolesPath)
+	data := &RoleData{ResourceData: ResourceData{Type: "roles"}, Attributes: &RoleAttributes{Name: "admin"}}
+	role, _, err := roles.Create(ctx, data)
+	if err != nil {
+		t.Fatalf("ResourceService.Create returned error: %v", err)
+	}
+	if role.ID != data.ID || role.Attributes.Name != "admin" {
+		t.Errorf("ResourceService.Create returned %+v, sent %+v", role, data)
+	}
+}
+
+func TestResourceService_FetchUpdateDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+rolesPath+"/"+testRoleID, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodPatch:
+			writeJSON(t, w, expectedRole)
+		case http.MethodDelete:
+			testQueryParam(t, r, "version", "3")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request method %v", r.Method)
+		}
+	})
+
+	roles := NewResourceService[RoleData](client, rolesPath)
+	role, _, err := roles.Fetch(ctx, testRoleID)
+	if err != nil || !reflect.DeepEqual(role, expectedRole.Data) {
+		t.Errorf("ResourceService.Fetch returned %+v, %v", role, err)
+	}
+	role, _, err = roles.Update(ctx, expectedRole.Data)
+	if err != nil || !reflect.DeepEqual(role, expectedRole.Data) {
+		t.Errorf("ResourceService.Update returned %+v, %v", role, err)
+	}
+	if _, err := roles.Delete(ctx, testRoleID, 3); err != nil {
+		t.Errorf("ResourceService.Delete returned error: %v", err)
+	}
+
+	if _, _, err := roles.Fetch(ctx, "../"+testRoleID); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("ResourceService.Fetch returned %v, want ErrInvalidUUID", err)
+	}
+	if _, _, err := roles.Update(ctx, &RoleData{}); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("ResourceService.Update returned %v, want ErrInvalidUUID", err)
+	}
+}
+
+func TestResourceService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+rolesPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testQueryParam(t, r, "page[number]", "1")
+		testQueryParam(t, r, "page[size]", "5")
+		testQueryParam(t, r, "filter[name]", "a&b")
+		writeJSON(t, w, ListDocument[RoleData]{Data: []*RoleData{expectedRole.Data}})
+	})
+
+	roles := NewResourceService[RoleData](client, rolesPath)
+	list, _, err := roles.List(ctx, &ListOptions{PageNumber: 1, PageSize: 5, Filter: map[string]string{"name": "a&b"}})
+	if err != nil {
+		t.Fatalf("ResourceService.List returned error: %v", err)
+	}
+	if !reflect.DeepEqual(list, []*RoleData{expectedRole.Data}) {
+		t.Errorf("ResourceService.List returned %+v", list)
+	}
+}
+
+func TestResourceService_Iterate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const total = 5
+	mux.HandleFunc("/v1/"+accountsPath, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+		list := AccountList{Data: []*AccountData{}}
+		for i := page * 2; i < total && i < page*2+2; i++ {
+			list.Data = append(list.Data, &AccountData{Version: i})
+		}
+		writeJSON(t, w, list)
+	})
+
+	it := client.Accounts.Iterate(&ListOptions{PageSize: 2})
+	var versions []int
+	for it.Next(ctx) {
+		versions = append(versions, it.Item().Version)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Iterator returned error: %v", err)
+	}
+	if want := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(versions, want) {
+		t.Errorf("Iterated versions %v, want %v", versions, want)
+	}
+}
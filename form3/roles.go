@@ -0,0 +1,244 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// rolesPath URL path to role resources.
+	rolesPath = "security/roles"
+	// acesPath URL path segment of access control entries of a role.
+	acesPath = "aces"
+)
+
+// Action is an operation an access control entry grants on a record type.
+type Action string
+
+// Actions that can be granted by an access control entry.
+const (
+	ActionCreate        Action = "CREATE"
+	ActionRead          Action = "READ"
+	ActionEdit          Action = "EDIT"
+	ActionDelete        Action = "DELETE"
+	ActionCreateApprove Action = "CREATE_APPROVE"
+	ActionEditApprove   Action = "EDIT_APPROVE"
+	ActionDeleteApprove Action = "DELETE_APPROVE"
+)
+
+// Role represents a named set of access control entries that can be granted to users.
+type Role struct {
+	Data *RoleData `json:"data"`
+}
+
+// RoleList represents a list of roles.
+type RoleList struct {
+	Data []*RoleData `json:"data"`
+}
+
+// RoleData represents the main attributes for a given role.
+type RoleData struct {
+	ResourceData
+	Attributes *RoleAttributes `json:"attributes"`
+}
+
+// RoleAttributes represents the available role attribute fields.
+type RoleAttributes struct {
+	Name string `json:"name"`
+}
+
+// ACE represents an access control entry, which grants a role an action on a record type.
+type ACE struct {
+	Data *ACEData `json:"data"`
+}
+
+// ACEList represents a list of access control entries.
+type ACEList struct {
+	Data []*ACEData `json:"data"`
+}
+
+// ACEData represents the main attributes for a given access control entry.
+type ACEData struct {
+	ResourceData
+	Attributes *ACEAttributes `json:"attributes"`
+}
+
+// ACEAttributes represents the available access control entry attribute fields.
+type ACEAttributes struct {
+	RoleID     UUID       `json:"role_id"`
+	Action     Action     `json:"action"`
+	RecordType RecordType `json:"record_type"`
+}
+
+// RolesService handles the communication with the role and access control entry
+// related methods of the Form3 API.
+//
+// Form3 API docs: https://api-docs.form3.tech/api.html?http#roles
+type RolesService service
+
+// Create creates a new role.
+// If Client.GenerateIDs is set and the role has no ID, a random one is assigned to role before sending.
+func (s *RolesService) Create(ctx context.Context, role *Role) (*Role, *http.Response, error) {
+	if role != nil {
+		generateID(s.client, role.Data)
+	}
+
+	request, err := s.client.NewRequest(http.MethodPost, rolesPath, role)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := new(Role)
+	resp, err := s.client.Do(ctx, request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// Fetch gets a single role using the role ID.
+func (s *RolesService) Fetch(ctx context.Context, roleID string) (*Role, *http.Response, error) {
+	if err := validateIDs(roleID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(rolesPath, roleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := new(Role)
+	resp, err := s.client.Do(ctx, request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// List lists all roles. Supports pagination.
+func (s *RolesService) List(ctx context.Context, pageNumber int, pageSize int) (*RoleList, *http.Response, error) {
+	path := s.client.listPath(rolesPath, pageNumber, pageSize)
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(RoleList)
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, nil
+}
+
+// Delete deletes a role by ID and given version.
+func (s *RolesService) Delete(ctx context.Context, roleID string, version int) (*http.Response, error) {
+	if err := validateIDs(roleID); err != nil {
+		return nil, err
+	}
+	path, err := joinPath(rolesPath, roleID)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("%s?version=%d", path, version), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, request, nil)
+}
+
+// CreateACE grants the role an action on a record type.
+// If Client.GenerateIDs is set and the entry has no ID, a random one is assigned to ace before sending.
+func (s *RolesService) CreateACE(ctx context.Context, roleID string, ace *ACE) (*ACE, *http.Response, error) {
+	if err := validateIDs(roleID); err != nil {
+		return nil, nil, err
+	}
+	if ace != nil {
+		generateID(s.client, ace.Data)
+	}
+	path, err := joinPath(rolesPath, roleID, acesPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodPost, path, ace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a := new(ACE)
+	resp, err := s.client.Do(ctx, request, a)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return a, resp, nil
+}
+
+// FetchACE gets a single access control entry of a role.
+func (s *RolesService) FetchACE(ctx context.Context, roleID string, aceID string) (*ACE, *http.Response, error) {
+	if err := validateIDs(roleID, aceID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(rolesPath, roleID, acesPath, aceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a := new(ACE)
+	resp, err := s.client.Do(ctx, request, a)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return a, resp, nil
+}
+
+// ListACEs lists the access control entries of a role.
+func (s *RolesService) ListACEs(ctx context.Context, roleID string) (*ACEList, *http.Response, error) {
+	if err := validateIDs(roleID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(rolesPath, roleID, acesPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(ACEList)
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, nil
+}
+
+// DeleteACE revokes an access control entry of a role.
+func (s *RolesService) DeleteACE(ctx context.Context, roleID string, aceID string) (*http.Response, error) {
+	if err := validateIDs(roleID, aceID); err != nil {
+		return nil, err
+	}
+	path, err := joinPath(rolesPath, roleID, acesPath, aceID)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, request, nil)
+}
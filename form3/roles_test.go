@@ -0,0 +1,144 @@
+package form3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testACEID = "c6e8a0c2-e4a6-4c8e-8a2c-6e8a0c2e4a6c"
+
+var expectedRole = &Role{Data: &RoleData{
+	ResourceData: ResourceData{Type: "roles", ID: testRoleID, OrganisationID: testOrganisationID},
+	Attributes:   &RoleAttributes{Name: "accounts-readonly"},
+}}
+
+var expectedACE = &ACE{Data: &ACEData{
+	ResourceData: ResourceData{Type: "aces", ID: testACEID, OrganisationID: testOrganisationID},
+	Attributes: &ACEAttributes{
+		RoleID:     testRoleID,
+		Action:     ActionRead,
+		RecordType: RecordTypeAccount,
+	},
+}}
+
+func TestRolesService_CRUD(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+rolesPath, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			writeJSON(t, w, expectedRole)
+		case http.MethodGet:
+			testQueryParam(t, r, "page[number]", "2")
+			writeJSON(t, w, RoleList{Data: []*RoleData{expectedRole.Data}})
+		default:
+			t.Errorf("Unexpected method %v", r.Method)
+		}
+	})
+	mux.HandleFunc("/v1/"+rolesPath+"/"+testRoleID, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(t, w, expectedRole)
+		case http.MethodDelete:
+			testQueryParam(t, r, "version", "0")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %v", r.Method)
+		}
+	})
+
+	created, _, err := client.Roles.Create(ctx, expectedRole)
+	if err != nil || !reflect.DeepEqual(created, expectedRole) {
+		t.Errorf("Roles.Create returned %+v, %v", created, err)
+	}
+
+	fetched, _, err := client.Roles.Fetch(ctx, testRoleID)
+	if err != nil || !reflect.DeepEqual(fetched, expectedRole) {
+		t.Errorf("Roles.Fetch returned %+v, %v", fetched, err)
+	}
+
+	list, _, err := client.Roles.List(ctx, 2, 10)
+	if err != nil || len(list.Data) != 1 {
+		t.Errorf("Roles.List returned %+v, %v", list, err)
+	}
+
+	if _, err := client.Roles.Delete(ctx, testRoleID, 0); err != nil {
+		t.Errorf("Roles.Delete returned error: %v", err)
+	}
+}
+
+func TestRolesService_ACEs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+rolesPath+"/"+testRoleID+"/aces", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			response, _ := json.Marshal(expectedACE)
+			testBody(t, r, bytes.NewBuffer(response))
+			writeJSON(t, w, expectedACE)
+		case http.MethodGet:
+			writeJSON(t, w, ACEList{Data: []*ACEData{expectedACE.Data}})
+		default:
+			t.Errorf("Unexpected method %v", r.Method)
+		}
+	})
+	mux.HandleFunc("/v1/"+rolesPath+"/"+testRoleID+"/aces/"+testACEID, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(t, w, expectedACE)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %v", r.Method)
+		}
+	})
+
+	created, _, err := client.Roles.CreateACE(ctx, testRoleID, expectedACE)
+	if err != nil || !reflect.DeepEqual(created, expectedACE) {
+		t.Errorf("Roles.CreateACE returned %+v, %v", created, err)
+	}
+
+	fetched, _, err := client.Roles.FetchACE(ctx, testRoleID, testACEID)
+	if err != nil || !reflect.DeepEqual(fetched, expectedACE) {
+		t.Errorf("Roles.FetchACE returned %+v, %v", fetched, err)
+	}
+
+	list, _, err := client.Roles.ListACEs(ctx, testRoleID)
+	if err != nil || len(list.Data) != 1 || list.Data[0].Attributes.Action != ActionRead {
+		t.Errorf("Roles.ListACEs returned %+v, %v", list, err)
+	}
+
+	if _, err := client.Roles.DeleteACE(ctx, testRoleID, testACEID); err != nil {
+		t.Errorf("Roles.DeleteACE returned error: %v", err)
+	}
+}
+
+func TestRolesService_invalidIDs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+	})
+
+	for _, id := range []string{"", "..", "x/aces"} {
+		if _, _, err := client.Roles.Fetch(ctx, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Roles.Fetch(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, _, err := client.Roles.CreateACE(ctx, id, &ACE{}); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Roles.CreateACE(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, _, err := client.Roles.ListACEs(ctx, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Roles.ListACEs(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, err := client.Roles.DeleteACE(ctx, testRoleID, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Roles.DeleteACE(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// subscriptionsPath URL path to subscription resources.
+	subscriptionsPath = "notification/subscriptions"
+)
+
+// CallbackTransport is the transport notifications are delivered through.
+type CallbackTransport string
+
+// Supported callback transports.
+const (
+	TransportHTTP  CallbackTransport = "http"
+	TransportQueue CallbackTransport = "queue"
+)
+
+// RecordType is the type of resource a subscription or notification refers to.
+type RecordType string
+
+// Record types that notifications can be subscribed to and access can be granted on.
+const (
+	RecordTypeAccount             RecordType = "accounts"
+	RecordTypePayment             RecordType = "payments"
+	RecordTypePaymentSubmission   RecordType = "payment_submissions"
+	RecordTypeMandate             RecordType = "mandates"
+	RecordTypeMandateSubmission   RecordType = "mandate_submissions"
+	RecordTypeDirectDebit         RecordType = "directdebits"
+	RecordTypeDirectDebitDecision RecordType = "directdebit_decisions"
+	RecordTypeDirectDebitReversal RecordType = "directdebit_reversals"
+	RecordTypeClaim               RecordType = "claims"
+	RecordTypeClaimSubmission     RecordType = "claim_submissions"
+	RecordTypeSubscription        RecordType = "subscriptions"
+	RecordTypeOrganisation        RecordType = "organisations"
+	RecordTypeUser                RecordType = "users"
+	RecordTypeRole                RecordType = "roles"
+	RecordTypeACE                 RecordType = "aces"
+)
+
+// EventType is the kind of change a subscription or notification refers to.
+type EventType string
+
+// Event types that notifications can be subscribed to.
+const (
+	EventTypeCreated EventType = "created"
+	EventTypeUpdated EventType = "updated"
+	EventTypeDeleted EventType = "deleted"
+)
+
+// Subscription represents the registration of a callback for notifications.
+type Subscription struct {
+	Data *SubscriptionData `json:"data"`
+}
+
+// SubscriptionList represents a list of subscriptions.
+type SubscriptionList struct {
+	Data []*SubscriptionData `json:"data"`
+}
+
+// SubscriptionData represents the main attributes for a given subscription.
+type SubscriptionData struct {
+	ResourceData
+	Attributes *SubscriptionAttributes `json:"attributes"`
+}
+
+// SubscriptionAttributes represents the available subscription attribute fields.
+type SubscriptionAttributes struct {
+	// CallbackURI is the URL or queue notifications are delivered to.
+	CallbackURI       string            `json:"callback_uri"`
+	CallbackTransport CallbackTransport `json:"callback_transport"`
+	RecordType        RecordType        `json:"record_type"`
+	EventType         EventType         `json:"event_type"`
+	// UserID is the user whose permissions are used to deliver the notifications.
+	UserID      UUID `json:"user_id,omitempty"`
+	Deactivated bool `json:"deactivated,omitempty"`
+}
+
+// SubscriptionsService handles the communication with the subscription related
+// methods of the Form3 API.
+//
+// Form3 API docs: https://api-docs.form3.tech/api.html?http#subscriptions
+type SubscriptionsService service
+
+// Create registers a new subscription.
+// If Client.GenerateIDs is set and the subscription has no ID, a random one is assigned to subscription before sending.
+func (s *SubscriptionsService) Create(ctx context.Context, subscription *Subscription) (*Subscription, *http.Response, error) {
+	if subscription != nil {
+		generateID(s.client, subscription.Data)
+	}
+
+	request, err := s.client.NewRequest(http.MethodPost, subscriptionsPath, subscription)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub := new(Subscription)
+	resp, err := s.client.Do(ctx, request, sub)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sub, resp, nil
+}
+
+// Fetch gets a single subscription using the subscription ID.
+func (s *SubscriptionsService) Fetch(ctx context.Context, subscriptionID string) (*Subscription, *http.Response, error) {
+	if err := validateIDs(subscriptionID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(subscriptionsPath, subscriptionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub := new(Subscription)
+	resp, err := s.client.Do(ctx, request, sub)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sub, resp, nil
+}
+
+// List lists all subscriptions. Supports pagination.
+func (s *SubscriptionsService) List(ctx context.Context, pageNumber int, pageSize int) (*SubscriptionList, *http.Response, error) {
+	path := s.client.listPath(subscriptionsPath, pageNumber, pageSize)
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(SubscriptionList)
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, nil
+}
+
+// Update changes the attributes of an existing subscription.
+// The subscription data must hold its ID and current version.
+func (s *SubscriptionsService) Update(ctx context.Context, subscription *Subscription) (*Subscription, *http.Response, error) {
+	if subscription == nil || subscription.Data == nil {
+		return nil, nil, errors.New("subscription data should not be nil")
+	}
+	subscriptionID := subscription.Data.ID.String()
+	if err := validateIDs(subscriptionID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(subscriptionsPath, subscriptionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodPatch, path, subscription)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub := new(Subscription)
+	resp, err := s.client.Do(ctx, request, sub)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sub, resp, nil
+}
+
+// Delete deletes a subscription by ID and given version.
+func (s *SubscriptionsService) Delete(ctx context.Context, subscriptionID string, version int) (*http.Response, error) {
+	if err := validateIDs(subscriptionID); err != nil {
+		return nil, err
+	}
+	path, err := joinPath(subscriptionsPath, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("%s?version=%d", path, version), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, request, nil)
+}
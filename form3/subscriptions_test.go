@@ -0,0 +1,159 @@
+package form3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testSubscriptionID = "9a0b6c2d-4e8f-4a1b-b3c5-d7e9f1a2b4c6"
+
+var expectedSubscription = &Subscription{Data: &SubscriptionData{
+	ResourceData: ResourceData{
+		Type:           "subscriptions",
+		ID:             testSubscriptionID,
+		OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		Version:        1,
+	},
+	Attributes: &SubscriptionAttributes{
+		CallbackURI:       "https://example.com/form3/callbacks",
+		CallbackTransport: TransportHTTP,
+		RecordType:        RecordTypeAccount,
+		EventType:         EventTypeCreated,
+		UserID:            "1f3e5a7c-9b2d-4f6e-8a0c-2e4f6a8b0c1d",
+	},
+}}
+
+func TestSubscriptionsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+subscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		response, _ := json.Marshal(expectedSubscription)
+		testBody(t, r, bytes.NewBuffer(response))
+		writeJSON(t, w, expectedSubscription)
+	})
+
+	sub, _, err := client.Subscriptions.Create(ctx, expectedSubscription)
+	if err != nil {
+		t.Errorf("Subscriptions.Create returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(sub, expectedSubscription) {
+		t.Errorf("Subscriptions.Create returned %+v, expected %+v", sub, expectedSubscription)
+	}
+}
+
+func TestSubscriptionsService_Fetch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+subscriptionsPath+"/"+testSubscriptionID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		writeJSON(t, w, expectedSubscription)
+	})
+
+	sub, _, err := client.Subscriptions.Fetch(ctx, testSubscriptionID)
+	if err != nil {
+		t.Errorf("Subscriptions.Fetch returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(sub, expectedSubscription) {
+		t.Errorf("Subscriptions.Fetch returned %+v, expected %+v", sub, expectedSubscription)
+	}
+}
+
+func TestSubscriptionsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+subscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testQueryParam(t, r, "page[number]", "0")
+		testQueryParam(t, r, "page[size]", "100")
+		writeJSON(t, w, SubscriptionList{Data: []*SubscriptionData{expectedSubscription.Data}})
+	})
+
+	list, _, err := client.Subscriptions.List(ctx, 0, 100)
+	if err != nil {
+		t.Errorf("Subscriptions.List returned error: %v", err)
+	}
+
+	if len(list.Data) != 1 || !reflect.DeepEqual(list.Data[0], expectedSubscription.Data) {
+		t.Errorf("Subscriptions.List returned %+v, expected %+v", list, expectedSubscription)
+	}
+}
+
+func TestSubscriptionsService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+subscriptionsPath+"/"+testSubscriptionID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		sub := new(Subscription)
+		if err := json.NewDecoder(r.Body).Decode(sub); err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+		if !sub.Data.Attributes.Deactivated {
+			t.Errorf("Update should send the changed attributes")
+		}
+		sub.Data.Version++
+		writeJSON(t, w, sub)
+	})
+
+	update := *expectedSubscription.Data
+	attributes := *update.Attributes
+	attributes.Deactivated = true
+	update.Attributes = &attributes
+
+	sub, _, err := client.Subscriptions.Update(ctx, &Subscription{Data: &update})
+	if err != nil {
+		t.Errorf("Subscriptions.Update returned error: %v", err)
+	}
+
+	if sub.Data.Version != 2 || !sub.Data.Attributes.Deactivated {
+		t.Errorf("Subscriptions.Update returned %+v", sub.Data)
+	}
+}
+
+func TestSubscriptionsService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+subscriptionsPath+"/"+testSubscriptionID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		testQueryParam(t, r, "version", "1")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Subscriptions.Delete(ctx, testSubscriptionID, 1)
+	if err != nil {
+		t.Errorf("Subscriptions.Delete returned error: %v", err)
+	}
+}
+
+func TestSubscriptionsService_invalidIDs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+	})
+
+	for _, id := range []string{"", "..", "?page[size]=1"} {
+		if _, _, err := client.Subscriptions.Fetch(ctx, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Subscriptions.Fetch(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		update := &Subscription{Data: &SubscriptionData{ResourceData: ResourceData{ID: UUID(id)}}}
+		if _, _, err := client.Subscriptions.Update(ctx, update); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Subscriptions.Update(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, err := client.Subscriptions.Delete(ctx, id, 0); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Subscriptions.Delete(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package form3
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// timestampLayout is the format Form3 uses for timestamps, e.g. 2020-05-06T09:28:13.843Z.
+const timestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// timestampLayouts holds the accepted timestamp formats in the order they are tried.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+}
+
+// Timestamp represents a time that can be unmarshalled from a Form3 API response.
+// Timestamps with and without fractional seconds and time zone are accepted;
+// a timestamp without zone is interpreted as UTC.
+// An unmodified Timestamp is marshalled back exactly as it was received.
+// The methods of time.Time, e.g. Before, After and Equal, compare it with a time.Time.
+type Timestamp struct {
+	time.Time
+	// raw holds the text the timestamp was parsed from.
+	raw string
+}
+
+// NewTimestamp returns a Timestamp for t.
+func NewTimestamp(t time.Time) *Timestamp {
+	return &Timestamp{Time: t}
+}
+
+// ParseTimestamp parses a timestamp in one of the formats used by Form3.
+func ParseTimestamp(s string) (Timestamp, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return Timestamp{Time: t, raw: s}, nil
+		}
+	}
+	return Timestamp{}, fmt.Errorf("invalid timestamp %q", s)
+}
+
+// String returns the timestamp in the format it would be marshalled to.
+func (t Timestamp) String() string {
+	if t.raw != "" {
+		if parsed, err := ParseTimestamp(t.raw); err == nil && parsed.Time.Equal(t.Time) {
+			return t.raw
+		}
+	}
+	return t.Time.UTC().Format(timestampLayout)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
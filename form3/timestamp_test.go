@@ -0,0 +1,71 @@
+package form3
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestamp_unmarshalFormats(t *testing.T) {
+	want := time.Date(2020, 5, 6, 9, 28, 13, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{in: `"2020-05-06T09:28:13Z"`, want: want},
+		{in: `"2020-05-06T09:28:13.843Z"`, want: want.Add(843 * time.Millisecond)},
+		{in: `"2020-05-06T10:28:13+01:00"`, want: want},
+		{in: `"2020-05-06T09:28:13"`, want: want},
+		{in: `"2020-05-06T09:28:13.5"`, want: want.Add(500 * time.Millisecond)},
+	}
+
+	for _, tt := range tests {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(tt.in), &ts); err != nil {
+			t.Errorf("Unmarshal(%s) returned error %v", tt.in, err)
+			continue
+		}
+		if !ts.Equal(tt.want) {
+			t.Errorf("Unmarshal(%s) is %v, want %v", tt.in, ts.Time, tt.want)
+		}
+
+		out, err := json.Marshal(ts)
+		if err != nil {
+			t.Errorf("Marshal returned error %v", err)
+		}
+		if string(out) != tt.in {
+			t.Errorf("Marshal is %s, want %s", out, tt.in)
+		}
+	}
+}
+
+func TestTimestamp_unmarshalInvalid(t *testing.T) {
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`"yesterday"`), &ts); err == nil {
+		t.Errorf("Unmarshal should return an error on invalid timestamp")
+	}
+}
+
+func TestTimestamp_marshalModified(t *testing.T) {
+	ts, _ := ParseTimestamp("2020-05-06T09:28:13Z")
+	ts.Time = ts.Time.Add(time.Second)
+
+	out, _ := json.Marshal(ts)
+	if want := `"2020-05-06T09:28:14.000Z"`; string(out) != want {
+		t.Errorf("Marshal is %s, want %s", out, want)
+	}
+}
+
+func TestAccountData_timestampsOmitted(t *testing.T) {
+	out, _ := json.Marshal(&AccountData{})
+	var fields map[string]interface{}
+	if err := json.Unmarshal(out, &fields); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	for _, key := range []string{"created_on", "modified_on"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("%s should be omitted, got %s", key, out)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package form3
+
+// Scheme is a payment scheme that transactions are processed through.
+type Scheme string
+
+// Schemes that direct debits and mandates can be processed through.
+const (
+	SchemeBacs            Scheme = "BACS"
+	SchemeSEPADirectDebit Scheme = "SEPADD"
+)
+
+// Party represents the debtor or creditor (beneficiary) side of a transaction resource.
+type Party struct {
+	AccountName                string       `json:"account_name,omitempty"`
+	AccountNumber              string       `json:"account_number,omitempty"`
+	AccountNumberCode          string       `json:"account_number_code,omitempty"`
+	AccountType                int          `json:"account_type,omitempty"`
+	AccountWith                *AccountWith `json:"account_with,omitempty"`
+	Address                    []string     `json:"address,omitempty"`
+	Country                    string       `json:"country,omitempty"`
+	Name                       string       `json:"name,omitempty"`
+	OrganisationIdentification string       `json:"organisation_identification,omitempty"`
+}
+
+// AccountWith identifies the bank that holds the account of a Party.
+type AccountWith struct {
+	BankID     string `json:"bank_id,omitempty"`
+	BankIDCode string `json:"bank_id_code,omitempty"`
+	Bic        string `json:"bic,omitempty"`
+}
+
+// ResourceData holds the fields shared by the data of every Form3 resource.
+type ResourceData struct {
+	Type           string     `json:"type"`
+	ID             UUID       `json:"id"`
+	OrganisationID UUID       `json:"organisation_id"`
+	Version        int        `json:"version"`
+	CreatedOn      *Timestamp `json:"created_on,omitempty"`
+	ModifiedOn     *Timestamp `json:"modified_on,omitempty"`
+	// Relationships links the resource to related resources, see Resolve.
+	Relationships Relationships `json:"relationships,omitempty"`
+}
@@ -0,0 +1,202 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// usersPath URL path to user resources.
+	usersPath = "security/users"
+	// credentialsPath URL path segment of user credentials.
+	credentialsPath = "credentials"
+)
+
+// User represents an API user.
+type User struct {
+	Data *UserData `json:"data"`
+}
+
+// UserList represents a list of API users.
+type UserList struct {
+	Data []*UserData `json:"data"`
+}
+
+// UserData represents the main attributes for a given user.
+type UserData struct {
+	ResourceData
+	Attributes *UserAttributes `json:"attributes"`
+}
+
+// UserAttributes represents the available user attribute fields.
+type UserAttributes struct {
+	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
+	// RoleIDs holds the IDs of the roles granted to the user.
+	RoleIDs []UUID `json:"role_ids,omitempty"`
+}
+
+// Credentials represents a client ID and secret used by an API user to authenticate.
+type Credentials struct {
+	Data *CredentialsData `json:"data"`
+}
+
+// CredentialsList represents the credentials of an API user.
+// Listed credentials never contain the client secret.
+type CredentialsList struct {
+	Data []*CredentialsData `json:"data"`
+}
+
+// CredentialsData represents a single client ID and secret.
+// The secret is only returned when the credentials are created.
+type CredentialsData struct {
+	ClientID     UUID   `json:"client_id"`
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// UsersService handles the communication with the user related
+// methods of the Form3 API.
+//
+// Form3 API docs: https://api-docs.form3.tech/api.html?http#users
+type UsersService service
+
+// Create creates a new API user.
+// If Client.GenerateIDs is set and the user has no ID, a random one is assigned to user before sending.
+func (s *UsersService) Create(ctx context.Context, user *User) (*User, *http.Response, error) {
+	if user != nil {
+		generateID(s.client, user.Data)
+	}
+
+	request, err := s.client.NewRequest(http.MethodPost, usersPath, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	u := new(User)
+	resp, err := s.client.Do(ctx, request, u)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return u, resp, nil
+}
+
+// Fetch gets a single user using the user ID.
+func (s *UsersService) Fetch(ctx context.Context, userID string) (*User, *http.Response, error) {
+	if err := validateIDs(userID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(usersPath, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	u := new(User)
+	resp, err := s.client.Do(ctx, request, u)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return u, resp, nil
+}
+
+// List lists all users. Supports pagination.
+func (s *UsersService) List(ctx context.Context, pageNumber int, pageSize int) (*UserList, *http.Response, error) {
+	path := s.client.listPath(usersPath, pageNumber, pageSize)
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(UserList)
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, nil
+}
+
+// Delete deletes a user by ID and given version.
+func (s *UsersService) Delete(ctx context.Context, userID string, version int) (*http.Response, error) {
+	if err := validateIDs(userID); err != nil {
+		return nil, err
+	}
+	path, err := joinPath(usersPath, userID)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("%s?version=%d", path, version), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, request, nil)
+}
+
+// CreateCredentials generates a new client ID and secret for a user.
+// The secret is only returned by this call and cannot be fetched again.
+func (s *UsersService) CreateCredentials(ctx context.Context, userID string) (*Credentials, *http.Response, error) {
+	if err := validateIDs(userID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(usersPath, userID, credentialsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := new(Credentials)
+	resp, err := s.client.Do(ctx, request, c)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return c, resp, nil
+}
+
+// ListCredentials lists the client IDs of a user.
+func (s *UsersService) ListCredentials(ctx context.Context, userID string) (*CredentialsList, *http.Response, error) {
+	if err := validateIDs(userID); err != nil {
+		return nil, nil, err
+	}
+	path, err := joinPath(usersPath, userID, credentialsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(CredentialsList)
+	resp, err := s.client.Do(ctx, request, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, nil
+}
+
+// DeleteCredentials revokes a client ID of a user.
+func (s *UsersService) DeleteCredentials(ctx context.Context, userID string, clientID string) (*http.Response, error) {
+	if err := validateIDs(userID, clientID); err != nil {
+		return nil, err
+	}
+	path, err := joinPath(usersPath, userID, credentialsPath, clientID)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.client.NewRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, request, nil)
+}
@@ -0,0 +1,138 @@
+package form3
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const (
+	testUserID   = "8c0e2a4c-6e8a-4c0e-9a2c-4e6a8c0e2a4c"
+	testClientID = "0e2a4c6e-8a0c-4e2a-8c6e-0a2c4e6a8c0e"
+	testRoleID   = "a4c6e8a0-c2e4-4a6c-8e0a-2c4e6a8c0e2a"
+)
+
+var expectedUser = &User{Data: &UserData{
+	ResourceData: ResourceData{Type: "users", ID: testUserID, OrganisationID: testOrganisationID},
+	Attributes: &UserAttributes{
+		Username: "payments-bot",
+		Email:    "payments@example.com",
+		RoleIDs:  []UUID{testRoleID},
+	},
+}}
+
+func TestUsersService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+usersPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		writeJSON(t, w, expectedUser)
+	})
+
+	u, _, err := client.Users.Create(ctx, expectedUser)
+	if err != nil {
+		t.Errorf("Users.Create returned error: %v", err)
+	}
+	if !reflect.DeepEqual(u, expectedUser) {
+		t.Errorf("Users.Create returned %+v, expected %+v", u, expectedUser)
+	}
+}
+
+func TestUsersService_FetchListDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+usersPath, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testQueryParam(t, r, "page[size]", "20")
+		writeJSON(t, w, UserList{Data: []*UserData{expectedUser.Data}})
+	})
+	mux.HandleFunc("/v1/"+usersPath+"/"+testUserID, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(t, w, expectedUser)
+		case http.MethodDelete:
+			testQueryParam(t, r, "version", "3")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %v", r.Method)
+		}
+	})
+
+	u, _, err := client.Users.Fetch(ctx, testUserID)
+	if err != nil || !reflect.DeepEqual(u, expectedUser) {
+		t.Errorf("Users.Fetch returned %+v, %v", u, err)
+	}
+
+	list, _, err := client.Users.List(ctx, 0, 20)
+	if err != nil || len(list.Data) != 1 {
+		t.Errorf("Users.List returned %+v, %v", list, err)
+	}
+
+	if _, err := client.Users.Delete(ctx, testUserID, 3); err != nil {
+		t.Errorf("Users.Delete returned error: %v", err)
+	}
+}
+
+func TestUsersService_Credentials(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/"+usersPath+"/"+testUserID+"/credentials", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			writeJSON(t, w, Credentials{Data: &CredentialsData{ClientID: testClientID, ClientSecret: "s3cret"}})
+		case http.MethodGet:
+			writeJSON(t, w, CredentialsList{Data: []*CredentialsData{{ClientID: testClientID}}})
+		default:
+			t.Errorf("Unexpected method %v", r.Method)
+		}
+	})
+	mux.HandleFunc("/v1/"+usersPath+"/"+testUserID+"/credentials/"+testClientID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	c, _, err := client.Users.CreateCredentials(ctx, testUserID)
+	if err != nil {
+		t.Fatalf("Users.CreateCredentials returned error: %v", err)
+	}
+	if c.Data.ClientID != testClientID || c.Data.ClientSecret != "s3cret" {
+		t.Errorf("Users.CreateCredentials returned %+v", c.Data)
+	}
+
+	list, _, err := client.Users.ListCredentials(ctx, testUserID)
+	if err != nil || len(list.Data) != 1 || list.Data[0].ClientSecret != "" {
+		t.Errorf("Users.ListCredentials returned %+v, %v", list, err)
+	}
+
+	if _, err := client.Users.DeleteCredentials(ctx, testUserID, testClientID); err != nil {
+		t.Errorf("Users.DeleteCredentials returned error: %v", err)
+	}
+}
+
+func TestUsersService_invalidIDs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+	})
+
+	for _, id := range []string{"", "..", "x/credentials"} {
+		if _, _, err := client.Users.Fetch(ctx, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Users.Fetch(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, err := client.Users.Delete(ctx, id, 0); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Users.Delete(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, _, err := client.Users.CreateCredentials(ctx, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Users.CreateCredentials(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+		if _, err := client.Users.DeleteCredentials(ctx, testUserID, id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("Users.DeleteCredentials(%q) returned %v, want ErrInvalidUUID", id, err)
+		}
+	}
+}
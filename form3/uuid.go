@@ -0,0 +1,75 @@
+package form3
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrInvalidUUID is returned when a resource or organisation identifier is not a valid UUID.
+var ErrInvalidUUID = errors.New("invalid UUID")
+
+// UUID represents a resource or organisation identifier in its canonical
+// textual form, e.g. ad27e265-9605-4b4b-a0e5-3003ea9cc4dc.
+type UUID string
+
+// NewUUID returns a new random (version 4) UUID.
+// It panics if the system random number generator fails.
+func NewUUID() UUID {
+	var b [16]byte
+	if _, err := io.ReadFull(rand.Reader, b[:]); err != nil {
+		panic(fmt.Sprintf("form3: could not generate UUID: %v", err))
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return UUID(fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]))
+}
+
+// ParseUUID parses s as a UUID in the canonical 8-4-4-4-12 hex form.
+// Upper case hex digits are accepted and normalised to lower case.
+func ParseUUID(s string) (UUID, error) {
+	if len(s) != 36 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+	}
+
+	b := []byte(s)
+	for i, c := range b {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return "", fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+			}
+		default:
+			switch {
+			case '0' <= c && c <= '9', 'a' <= c && c <= 'f':
+			case 'A' <= c && c <= 'F':
+				b[i] = c + 'a' - 'A'
+			default:
+				return "", fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+			}
+		}
+	}
+	return UUID(b), nil
+}
+
+// Validate returns an error wrapping ErrInvalidUUID if u is not a valid UUID.
+func (u UUID) Validate() error {
+	_, err := ParseUUID(string(u))
+	return err
+}
+
+// String returns the textual form of u.
+func (u UUID) String() string {
+	return string(u)
+}
+
+// validateIDs returns an error wrapping ErrInvalidUUID for the first of ids that is not a valid UUID.
+func validateIDs(ids ...string) error {
+	for _, id := range ids {
+		if err := UUID(id).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
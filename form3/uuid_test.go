@@ -0,0 +1,81 @@
+package form3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestNewUUID(t *testing.T) {
+	u := NewUUID()
+	if err := u.Validate(); err != nil {
+		t.Errorf("NewUUID returned invalid UUID %v: %v", u, err)
+	}
+	if got := u.String()[14]; got != '4' {
+		t.Errorf("NewUUID version is %c, want 4", got)
+	}
+	if NewUUID() == u {
+		t.Errorf("NewUUID returned the same UUID twice")
+	}
+}
+
+func TestParseUUID(t *testing.T) {
+	got, err := ParseUUID("AD27E265-9605-4B4B-A0E5-3003EA9CC4DC")
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if got != testAccountID {
+		t.Errorf("ParseUUID is %v, want %v", got, testAccountID)
+	}
+
+	for _, in := range []string{"", "1", "ad27e265-9605-4b4b-a0e5-3003ea9cc4d", "ad27e265-9605-4b4b-a0e5_3003ea9cc4dc", "zd27e265-9605-4b4b-a0e5-3003ea9cc4dc"} {
+		if _, err := ParseUUID(in); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("ParseUUID(%q) returned %v, want ErrInvalidUUID", in, err)
+		}
+	}
+}
+
+func TestAccountsService_invalidID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+	})
+
+	if _, _, err := client.Accounts.Fetch(ctx, ""); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("Accounts.Fetch returned %v, want ErrInvalidUUID", err)
+	}
+	if _, err := client.Accounts.Delete(ctx, "not-a-uuid", 0); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("Accounts.Delete returned %v, want ErrInvalidUUID", err)
+	}
+}
+
+func TestAccountsService_CreateGeneratesID(t *testing.T) {
+	setup()
+	defer teardown()
+	client.GenerateIDs = true
+
+	mux.HandleFunc("/v1/"+accountsPath, func(w http.ResponseWriter, r *http.Request) {
+		account := new(Account)
+		if err := json.NewDecoder(r.Body).Decode(account); err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+		if err := account.Data.ID.Validate(); err != nil {
+			t.Errorf("Create sent invalid ID: %v", err)
+		}
+		response, _ := json.Marshal(account)
+		fmt.Fprint(w, string(response))
+	})
+
+	account := &Account{Data: &AccountData{Attributes: &AccountAttributes{Country: "GB"}}}
+	created, _, err := client.Accounts.Create(ctx, account)
+	if err != nil {
+		t.Fatalf("Accounts.Create returned error: %v", err)
+	}
+	if created.Data.ID == "" || created.Data.ID != account.Data.ID {
+		t.Errorf("Accounts.Create returned ID %q, sent %q", created.Data.ID, account.Data.ID)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/martoup/go-form3
 
-go 1.14
+go 1.18
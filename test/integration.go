@@ -3,33 +3,16 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/martoup/go-form3/form3"
+	"github.com/martoup/go-form3/form3/form3test"
 	"log"
+	"net/http"
+	"os"
 	"reflect"
-	"strings"
 )
 
-const accJson = `
-{
-  "data": {
-    "type": "accounts",
-    "id": "1227e265-9605-4b4b-a0e5-3003ea9cc4dc",
-    "organisation_id": "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
-    "attributes": {
-      "country": "GB",
-      "base_currency": "GBP",
-      "bank_id": "400300",
-      "bank_id_code": "GBDSC",
-      "bic": "NWBKGB22",
-      "account_classification": "Personal",
-      "joint_account": false,
-      "account_matching_opt_out": false,
-      "secondary_identification": "A1B2C3D4"
-    }
-  }
-}`
-
 func main() {
 	client, err := form3.NewClientFromEnvironment()
 
@@ -37,77 +20,114 @@ func main() {
 		log.Fatalf("Failed to create a client %v", err)
 	}
 
-	account := &form3.Account{}
-	err = json.NewDecoder(strings.NewReader(accJson)).Decode(account)
+	// FORM3_RECORD_FIXTURE records the interactions of this run to a fixture file for replay in unit tests,
+	// e.g. form3/testdata/accounts.json.
+	var recorder *form3test.Recorder
+	if fixture, ok := os.LookupEnv("FORM3_RECORD_FIXTURE"); ok {
+		recorder, err = form3test.NewRecorder(fixture, &form3test.RecorderOptions{Mode: form3test.ModeRecord})
+		if err != nil {
+			log.Fatalf("Failed to create a recorder %v", err)
+		}
+		client, err = form3.NewClient(os.Getenv("FORM3_BASE_URL"), &http.Client{Transport: recorder})
+		if err != nil {
+			log.Fatalf("Failed to create a client %v", err)
+		}
+	}
+
+	err = run(client)
+
+	// The fixture is written whether or not the run succeeded, so a failing run can be inspected.
+	if recorder != nil {
+		if stopErr := recorder.Stop(); stopErr != nil {
+			log.Printf("Failed to write fixture %v", stopErr)
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Print("===== Success! =====")
+}
+
+func run(client *form3.Client) error {
+	account, err := form3.NewAccountBuilder().
+		UK().
+		ID("1227e265-9605-4b4b-a0e5-3003ea9cc4dc").
+		OrganisationID("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").
+		SortCode("400300").
+		BIC("NWBKGB22").
+		Name("Samantha Holder").
+		AlternativeNames("Sam Holder").
+		Classification(form3.AccountTypePersonal).
+		SecondaryIdentification("A1B2C3D4").
+		Build()
 
 	if err != nil {
-		log.Fatalf("Failed to decode test JSON %v", err)
+		return fmt.Errorf("failed to build test account: %w", err)
 	}
 
-	fmt.Printf("==== Step 1/5 Create account: %v\n", accJson)
+	fmt.Printf("==== Step 1/5 Create account: %v\n", printJSON(account))
 	create, _, err := client.Accounts.Create(context.Background(), account)
 
 	if err != nil {
-		log.Fatalf("Failed to create account %v", err)
+		return fmt.Errorf("failed to create account: %w", err)
 	}
 
 	fmt.Printf("Response: %+v\n\n", printJSON(create))
-	checkJSON(create)
+	if err := checkAccount(create, account); err != nil {
+		return err
+	}
 
 	fmt.Printf("==== Step 2/5 Get single account with ID %s\n", account.Data.ID)
-	fetch, _, err := client.Accounts.Fetch(context.Background(), account.Data.ID)
+	fetch, _, err := client.Accounts.Fetch(context.Background(), account.Data.ID.String())
 
 	if err != nil {
-		log.Fatalf("Failed to fetch account %v\n", err)
+		return fmt.Errorf("failed to fetch account: %w", err)
 	}
 	fmt.Printf("Response: %+v\n\n", printJSON(fetch))
-	checkJSON(fetch)
+	if err := checkAccount(fetch, account); err != nil {
+		return err
+	}
 
 	fmt.Print("==== Step 3/5 Get account list:\n")
 	list, _, err := client.Accounts.List(context.Background(), 0, 0)
 
 	if err != nil {
-		log.Fatalf("Failed to get account list %v", err)
+		return fmt.Errorf("failed to get account list: %w", err)
 	}
 
 	fmt.Printf("Response: %+v\n\n", printJSON(list))
 
 	fmt.Printf("==== Step 4/5 Delete account with ID: %s and Version: %d \n", account.Data.ID, account.Data.Version)
-	_, err = client.Accounts.Delete(context.Background(), account.Data.ID, account.Data.Version)
+	_, err = client.Accounts.Delete(context.Background(), account.Data.ID.String(), account.Data.Version)
 
 	if err != nil {
-		log.Fatalf("Failed to delete account %v", err)
+		return fmt.Errorf("failed to delete account: %w", err)
 	}
 
 	fmt.Print("==== Step 5/5 Get single (now deleted) account:\n")
-	fetch, _, err = client.Accounts.Fetch(context.Background(), account.Data.ID)
+	_, _, err = client.Accounts.Fetch(context.Background(), account.Data.ID.String())
 
 	if err == nil {
-		log.Fatal(err)
+		return errors.New("fetching the deleted account succeeded")
 	}
 
 	fmt.Printf("Response should be 404 (we deleted the account), actual response is: %+v\n", err)
-	fmt.Print("===== Success! =====")
+	return nil
 }
 
 func printJSON(body interface{}) string {
 	marshal, err := json.Marshal(body)
 	if err != nil {
-		log.Fatalf("Failed to marshal body to print %v", err)
+		return fmt.Sprintf("(failed to marshal body to print %v)", err)
 	}
 	return string(marshal)
 }
 
-func checkJSON(act *form3.Account) {
+func checkAccount(act *form3.Account, exp *form3.Account) error {
 	fmt.Print("Checking Response... \n")
-	exp := form3.Account{}
-	err := json.Unmarshal([]byte(accJson), &exp)
-	if err != nil {
-		log.Fatalf("Failed to marshal body %v", err)
-	}
-
 	if !reflect.DeepEqual(act.Data.Attributes, exp.Data.Attributes) {
-		log.Fatalf("Objects do not match %+v, expected %+v", act, exp)
+		return fmt.Errorf("objects do not match %+v, expected %+v", act, exp)
 	}
 	fmt.Print("Asserting Objects successful.\n")
+	return nil
 }